@@ -3,76 +3,419 @@
 package main
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/deeprajsshetty/alarm-service/internal/handlers"
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
 	"github.com/deeprajsshetty/alarm-service/internal/services"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
 )
 
-const defaultPort = "8080"
+const (
+	defaultPort      = "8080"
+	defaultStoreType = "memory"
+	defaultDataDir   = "data"
+)
+
+// getPort retrieves the server port from environment variables or defaults to 8080.
+func getPort() string {
+	port := os.Getenv("PORT")
+	if port == "" {
+		port = defaultPort
+	}
+	return port
+}
+
+// getShutdownTimeout retrieves the graceful shutdown deadline from the SHUTDOWN_TIMEOUT
+// environment variable (a Go duration such as "15s") or defaults to 30 seconds.
+func getShutdownTimeout() (time.Duration, error) {
+	value := os.Getenv("SHUTDOWN_TIMEOUT")
+	if value == "" {
+		return services.DefaultShutdownTimeout, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil || timeout <= 0 {
+		return 0, fmt.Errorf("invalid SHUTDOWN_TIMEOUT %q", value)
+	}
+	return timeout, nil
+}
 
-// initializeRoutes configures HTTP endpoints for the Alarm Service.
-func initializeRoutes(handler *handlers.AlarmHandler) {
-	http.HandleFunc("/alarms", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			handler.GetAllAlarms(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+// getReopenWindow retrieves how long after being cleared a repeated alarm is reopened from
+// the REOPEN_WINDOW environment variable (a Go duration, "0s" always raises a new instance)
+// or defaults to 15 minutes.
+func getReopenWindow() (time.Duration, error) {
+	value := os.Getenv("REOPEN_WINDOW")
+	if value == "" {
+		return services.DefaultReopenWindow, nil
+	}
+	window, err := time.ParseDuration(value)
+	if err != nil || window < 0 {
+		return 0, fmt.Errorf("invalid REOPEN_WINDOW %q", value)
+	}
+	return window, nil
+}
+
+// getInhibitRules reads the inhibit rules from the JSON file named by the INHIBIT_RULES_FILE
+// environment variable. No alarm is inhibited when it is not set.
+func getInhibitRules() ([]models.InhibitRule, error) {
+	path := os.Getenv("INHIBIT_RULES_FILE")
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var rules []models.InhibitRule
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return nil, fmt.Errorf("invalid INHIBIT_RULES_FILE %q: %w", path, err)
+	}
+	for _, rule := range rules {
+		if err := rule.Validate(); err != nil {
+			return nil, fmt.Errorf("invalid INHIBIT_RULES_FILE %q: %w", path, err)
 		}
-	})
+	}
+	return rules, nil
+}
+
+// getOnCall reads the on-call users and schedules from the JSON file named by the ONCALL_FILE
+// environment variable and checks that users are paged through registered notifiers. No
+// schedule is configured when it is not set.
+func getOnCall(notifiers *notify.Registry) (models.OnCallConfig, error) {
+	path := os.Getenv("ONCALL_FILE")
+	if path == "" {
+		return models.OnCallConfig{}, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.OnCallConfig{}, err
+	}
 
-	http.HandleFunc("/alarm", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodGet:
-			handler.GetAlarmByID(w, r)
-		case http.MethodPost:
-			handler.CreateAlarm(w, r)
-		case http.MethodPut:
-			handler.UpdateAlarmState(w, r)
-		case http.MethodDelete:
-			handler.DeleteAlarm(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	var config models.OnCallConfig
+	if err := json.Unmarshal(data, &config); err != nil {
+		return models.OnCallConfig{}, fmt.Errorf("invalid ONCALL_FILE %q: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return models.OnCallConfig{}, fmt.Errorf("invalid ONCALL_FILE %q: %w", path, err)
+	}
+	registered := notifierNames(notifiers)
+	for _, user := range config.Users {
+		for _, notifier := range user.Notifiers {
+			if !registered[notifier] {
+				return models.OnCallConfig{}, fmt.Errorf("invalid ONCALL_FILE %q: unknown notifier %q for user %q", path, notifier, user.ID)
+			}
 		}
-	})
+	}
+	return config, nil
+}
+
+// getEscalationPolicies reads the escalation policies from the JSON file named by the
+// ESCALATION_POLICIES_FILE environment variable and checks that their targets are registered
+// notifiers or configured on-call schedules. No alarm escalates when it is not set.
+func getEscalationPolicies(notifiers *notify.Registry, onCall models.OnCallConfig) ([]models.EscalationPolicy, error) {
+	path := os.Getenv("ESCALATION_POLICIES_FILE")
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
 
-	http.HandleFunc("/alarms/bulk", func(w http.ResponseWriter, r *http.Request) {
-		switch r.Method {
-		case http.MethodPost:
-			handler.BulkCreateAlarms(w, r)
-		default:
-			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	var policies []models.EscalationPolicy
+	if err := json.Unmarshal(data, &policies); err != nil {
+		return nil, fmt.Errorf("invalid ESCALATION_POLICIES_FILE %q: %w", path, err)
+	}
+	if err := models.ValidateEscalationPolicies(policies); err != nil {
+		return nil, fmt.Errorf("invalid ESCALATION_POLICIES_FILE %q: %w", path, err)
+	}
+	registered := targetNames(notifiers, onCall)
+	for _, policy := range policies {
+		for _, tier := range policy.Tiers {
+			for _, target := range tier.Targets {
+				if !registered[target] {
+					return nil, fmt.Errorf("invalid ESCALATION_POLICIES_FILE %q: unknown target %q in policy %q", path, target, policy.Name)
+				}
+			}
+		}
+	}
+	return policies, nil
+}
+
+// notifierNames returns the set of names of the registered notifiers.
+func notifierNames(notifiers *notify.Registry) map[string]bool {
+	names := make(map[string]bool)
+	for _, notifier := range notifiers.Notifiers() {
+		names[notifier.Name()] = true
+	}
+	return names
+}
+
+// targetNames returns the set of names of the registered notifiers and configured on-call
+// schedules that escalation policies and routes can target.
+func targetNames(notifiers *notify.Registry, onCall models.OnCallConfig) map[string]bool {
+	names := notifierNames(notifiers)
+	for _, schedule := range onCall.Schedules {
+		names[models.ScheduleTargetPrefix+schedule.ID] = true
+	}
+	return names
+}
+
+// getRoutes reads the notification routing tree from the JSON file named by the ROUTES_FILE
+// environment variable and checks that its receivers are registered notifiers or configured
+// on-call schedules. Notifications are delivered to every notifier when it is not set.
+func getRoutes(notifiers *notify.Registry, onCall models.OnCallConfig) (*models.Route, error) {
+	path := os.Getenv("ROUTES_FILE")
+	if path == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var root models.Route
+	if err := json.Unmarshal(data, &root); err != nil {
+		return nil, fmt.Errorf("invalid ROUTES_FILE %q: %w", path, err)
+	}
+	if err := root.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid ROUTES_FILE %q: %w", path, err)
+	}
+	registered := targetNames(notifiers, onCall)
+	var unknown error
+	root.Walk(func(route models.Route) {
+		for _, receiver := range route.Receivers {
+			if !registered[receiver] && unknown == nil {
+				unknown = fmt.Errorf("invalid ROUTES_FILE %q: unknown receiver %q", path, receiver)
+			}
 		}
 	})
+	if unknown != nil {
+		return nil, unknown
+	}
+	return &root, nil
 }
 
-// getPort retrieves the server port from environment variables or defaults to 8080.
-func getPort() string {
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = defaultPort
+// getStore builds the alarm store selected by the STORE_TYPE environment variable.
+// Supported values are "memory" (default) and "file"; the file store persists to DATA_DIR.
+func getStore() (store.AlarmStore, error) {
+	storeType := os.Getenv("STORE_TYPE")
+	if storeType == "" {
+		storeType = defaultStoreType
 	}
-	return port
+
+	switch storeType {
+	case "memory":
+		return store.NewMemoryStore(), nil
+	case "file":
+		dataDir := os.Getenv("DATA_DIR")
+		if dataDir == "" {
+			dataDir = defaultDataDir
+		}
+		return store.NewFileStore(dataDir)
+	default:
+		return nil, fmt.Errorf("unknown store type %q", storeType)
+	}
+}
+
+// getNotifiers builds the registry of notifiers that alarm notifications are delivered to.
+// The console notifier is always registered; a webhook notifier is added when WEBHOOK_URLS
+// (comma-separated) is set, signing payloads with WEBHOOK_SECRET unless WEBHOOK_UNSIGNED is
+// "true", and an email notifier when SMTP_HOST is set.
+func getNotifiers() (*notify.Registry, error) {
+	registry, err := notify.NewRegistry(notify.NewConsoleNotifier(os.Stdout))
+	if err != nil {
+		return nil, err
+	}
+
+	if value := os.Getenv("WEBHOOK_URLS"); value != "" {
+		urls := splitList(value)
+		if len(urls) == 0 {
+			return nil, fmt.Errorf("invalid WEBHOOK_URLS %q: no URL given", value)
+		}
+		webhook, err := notify.NewWebhookNotifier(notify.WebhookConfig{
+			URLs:     urls,
+			Secret:   os.Getenv("WEBHOOK_SECRET"),
+			Unsigned: os.Getenv("WEBHOOK_UNSIGNED") == "true",
+		})
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(webhook); err != nil {
+			return nil, err
+		}
+	}
+
+	if host := os.Getenv("SMTP_HOST"); host != "" {
+		email, err := getEmailNotifier(host)
+		if err != nil {
+			return nil, err
+		}
+		if err := registry.Register(email); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// getEmailNotifier builds an email notifier for the SMTP relay at host from the
+// SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD, SMTP_STARTTLS, SMTP_FROM and SMTP_TO
+// (comma-separated) environment variables.
+func getEmailNotifier(host string) (*notify.EmailNotifier, error) {
+	to := splitList(os.Getenv("SMTP_TO"))
+	if len(to) == 0 {
+		return nil, fmt.Errorf("invalid SMTP_TO %q: no recipient given", os.Getenv("SMTP_TO"))
+	}
+	config := notify.EmailConfig{
+		Host:     host,
+		Username: os.Getenv("SMTP_USERNAME"),
+		Password: os.Getenv("SMTP_PASSWORD"),
+		StartTLS: os.Getenv("SMTP_STARTTLS") == "true",
+		From:     os.Getenv("SMTP_FROM"),
+		To:       to,
+	}
+	if port := os.Getenv("SMTP_PORT"); port != "" {
+		value, err := strconv.Atoi(port)
+		if err != nil {
+			return nil, fmt.Errorf("invalid SMTP_PORT %q: %w", port, err)
+		}
+		config.Port = value
+	}
+	return notify.NewEmailNotifier(config)
+}
+
+// splitList splits a comma-separated environment variable value into its entries, trimming
+// surrounding whitespace and dropping empty entries.
+func splitList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
 }
 
 // main initializes the application and starts the server.
 func main() {
 	log.Println("Starting Alarm Service...")
+	if err := run(); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Alarm Service stopped")
+}
+
+// run builds the alarm service from the environment and serves it until the server fails or
+// the process is interrupted, then shuts it down. Errors are returned rather than exiting, so
+// that deferred cleanup always runs.
+func run() error {
+	shutdownTimeout, err := getShutdownTimeout()
+	if err != nil {
+		return fmt.Errorf("failed to read configuration: %w", err)
+	}
 
 	// Initialize dependencies
-	service := services.NewAlarmService()
+	alarmStore, err := getStore()
+	if err != nil {
+		return fmt.Errorf("failed to initialize store: %w", err)
+	}
+	service, err := newService(alarmStore)
+	if err != nil {
+		alarmStore.Close()
+		return err
+	}
 	handler := handlers.NewAlarmHandler(service)
 
-	// Setup routes
-	initializeRoutes(handler)
-
 	// Start server
 	port := getPort()
-	log.Printf("Server running on port %s", port)
-	if err := http.ListenAndServe(":"+port, nil); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	server := &http.Server{Addr: ":" + port, Handler: handlers.NewRouter(handler)}
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	serverErr := make(chan error, 1)
+	go func() {
+		log.Printf("Server running on port %s", port)
+		serverErr <- server.ListenAndServe()
+	}()
+
+	var runErr error
+	select {
+	case err := <-serverErr:
+		runErr = fmt.Errorf("failed to start server: %w", err)
+	case <-ctx.Done():
+		// Stop accepting requests and let in-flight ones finish within their own deadline.
+		log.Printf("Shutting down (timeout %s)...", shutdownTimeout)
+		serverCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(serverCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Printf("Failed to shut down server gracefully: %v", err)
+		}
+	}
+
+	// Drain pending notifications and flush the store within a deadline of their own, so a
+	// slow request cannot use up the time left to persist alarms.
+	closeCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := service.Close(closeCtx); err != nil {
+		log.Printf("Failed to close alarm service gracefully: %v", err)
+	}
+	return runErr
+}
+
+// newService reads the notifiers, alarm handling and on-call configuration from the
+// environment and builds the alarm service on alarmStore.
+func newService(alarmStore store.AlarmStore) (*services.AlarmService, error) {
+	notifiers, err := getNotifiers()
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize notifiers: %w", err)
+	}
+	reopenWindow, err := getReopenWindow()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	inhibitRules, err := getInhibitRules()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	onCall, err := getOnCall(notifiers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	escalationPolicies, err := getEscalationPolicies(notifiers, onCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	routes, err := getRoutes(notifiers, onCall)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration: %w", err)
+	}
+	opts := []services.Option{
+		services.WithStore(alarmStore),
+		services.WithNotifiers(notifiers),
+		services.WithReopenWindow(reopenWindow),
+		services.WithInhibitRules(inhibitRules...),
+		services.WithEscalationPolicies(escalationPolicies...),
+		services.WithOnCall(onCall),
+	}
+	if routes != nil {
+		opts = append(opts, services.WithRoutes(*routes))
+	}
+	service, err := services.NewAlarmService(opts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize alarm service: %w", err)
 	}
+	return service, nil
 }
@@ -1,8 +1,16 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/deeprajsshetty/alarm-service/internal/models"
 	"github.com/deeprajsshetty/alarm-service/internal/services"
@@ -22,48 +30,127 @@ func NewAlarmHandler(service *services.AlarmService) *AlarmHandler {
 func (h *AlarmHandler) CreateAlarm(w http.ResponseWriter, r *http.Request) {
 	var alarm models.Alarm
 	if err := json.NewDecoder(r.Body).Decode(&alarm); err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
 		return
 	}
 
-	createdAlarm, err := h.service.CreateAlarm(alarm)
+	createdAlarm, err := h.service.CreateAlarm(auditContext(r), alarm)
 	if err != nil {
-		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		h.respondWithServiceError(w, err)
 		return
 	}
 
 	h.respondWithJSON(w, http.StatusCreated, createdAlarm)
 }
 
-// BulkCreateAlarms handles bulk creation of multiple alarms.
+// BulkItemResult is the outcome of a single item of a bulk request.
+type BulkItemResult struct {
+	Index  int           `json:"index"`           // Position of the item in the request
+	Status int           `json:"status"`          // HTTP status code of the item
+	Alarm  *models.Alarm `json:"alarm,omitempty"` // Alarm as created, updated or deleted
+	Error  *Problem      `json:"error,omitempty"` // Why the item failed
+}
+
+// BulkCreateAlarms handles bulk creation of multiple alarms. With `atomic=true` either
+// all alarms are created or, if any is invalid, none.
 func (h *AlarmHandler) BulkCreateAlarms(w http.ResponseWriter, r *http.Request) {
 	var alarms []models.Alarm
 	if err := json.NewDecoder(r.Body).Decode(&alarms); err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid request payload for bulk creation")
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload for bulk creation")
+		return
+	}
+	opts, err := parseBulkOptions(r)
+	if err != nil {
+		h.respondWithServiceError(w, err)
 		return
 	}
 
-	createdAlarms, err := h.service.BulkCreateAlarms(alarms)
+	results, err := h.service.CreateAlarms(auditContext(r), alarms, opts)
+	h.respondWithBulkResults(w, http.StatusCreated, results, err)
+}
+
+// BulkUpdateAlarmStates handles bulk lifecycle state updates, applied in request order.
+// With `atomic=true` either all updates are applied or, if any fails, none.
+func (h *AlarmHandler) BulkUpdateAlarmStates(w http.ResponseWriter, r *http.Request) {
+	var updates []models.StateUpdate
+	if err := json.NewDecoder(r.Body).Decode(&updates); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload for bulk update")
+		return
+	}
+	opts, err := parseBulkOptions(r)
 	if err != nil {
-		h.respondWithError(w, http.StatusBadRequest, err.Error())
+		h.respondWithServiceError(w, err)
 		return
 	}
 
-	h.respondWithJSON(w, http.StatusCreated, createdAlarms)
+	results, err := h.service.UpdateAlarmStates(auditContext(r), updates, opts)
+	h.respondWithBulkResults(w, http.StatusOK, results, err)
 }
 
-// GetAllAlarms retrieves and returns all alarms.
+// BulkDeleteAlarms handles bulk deletion of the alarms whose IDs are given as a JSON array.
+// With `atomic=true` either all alarms are deleted or, if any does not exist, none.
+func (h *AlarmHandler) BulkDeleteAlarms(w http.ResponseWriter, r *http.Request) {
+	var ids []string
+	if err := json.NewDecoder(r.Body).Decode(&ids); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload for bulk deletion")
+		return
+	}
+	opts, err := parseBulkOptions(r)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	results, err := h.service.DeleteAlarms(auditContext(r), ids, opts)
+	h.respondWithBulkResults(w, http.StatusOK, results, err)
+}
+
+// GetAllAlarms retrieves and returns the alarms matching the query parameters parsed by
+// parseAlarmQuery. The body is a JSON array; when more alarms match than `limit`, the
+// cursor of the next page is returned in the X-Next-Cursor header.
 func (h *AlarmHandler) GetAllAlarms(w http.ResponseWriter, r *http.Request) {
-	alarms := h.service.GetAllAlarms()
-	h.respondWithJSON(w, http.StatusOK, alarms)
+	query, err := parseAlarmQuery(r.URL.Query())
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	page, err := h.service.ListAlarms(r.Context(), query)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	if page.NextCursor != "" {
+		w.Header().Set("X-Next-Cursor", page.NextCursor)
+	}
+	h.respondWithJSON(w, http.StatusOK, page.Alarms)
+}
+
+// ListAlarms retrieves a page of the alarms matching the query parameters parsed by
+// parseAlarmQuery, wrapped together with the cursor of the next page.
+func (h *AlarmHandler) ListAlarms(w http.ResponseWriter, r *http.Request) {
+	query, err := parseAlarmQuery(r.URL.Query())
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	page, err := h.service.ListAlarms(r.Context(), query)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, page)
 }
 
 // GetAlarmByID retrieves a specific alarm by its ID.
 func (h *AlarmHandler) GetAlarmByID(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("id")
+	id := alarmID(r)
 	alarm, err := h.service.GetAlarmByID(id)
 	if err != nil {
-		h.respondWithError(w, http.StatusNotFound, "Alarm not found")
+		h.respondWithServiceError(w, err)
 		return
 	}
 
@@ -72,37 +159,222 @@ func (h *AlarmHandler) GetAlarmByID(w http.ResponseWriter, r *http.Request) {
 
 // UpdateAlarmState updates an existing alarm's state by ID.
 func (h *AlarmHandler) UpdateAlarmState(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("id")
+	id := alarmID(r)
 
 	var request struct {
 		State models.AlarmState `json:"state"`
 	}
 	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
-		h.respondWithError(w, http.StatusBadRequest, "Invalid request payload")
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
 		return
 	}
 
-	alarm, err := h.service.UpdateAlarmState(id, request.State)
+	alarm, err := h.service.UpdateAlarmState(auditContext(r), id, request.State)
 	if err != nil {
-		h.respondWithError(w, http.StatusNotFound, "Alarm not found")
+		h.respondWithServiceError(w, err)
 		return
 	}
 
 	h.respondWithJSON(w, http.StatusOK, alarm)
 }
 
+// ReopenAlarm moves a Cleared alarm back to Triggered.
+func (h *AlarmHandler) ReopenAlarm(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	alarm, err := h.service.ReopenAlarm(auditContext(r), id)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// ReportCondition records a process condition change reported by the alarm source.
+func (h *AlarmHandler) ReportCondition(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	var request struct {
+		Condition models.AlarmCondition `json:"condition"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	alarm, err := h.service.ReportCondition(auditContext(r), id, request.Condition)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// AcknowledgeAlarm records an operator acknowledgement of an alarm. The optional body names
+// the acknowledging user, which defaults to the X-Actor header, and a comment.
+func (h *AlarmHandler) AcknowledgeAlarm(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	ack, err := decodeAckRequest(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	alarm, err := h.service.AcknowledgeAlarm(auditContext(r), id, ack)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// UnacknowledgeAlarm reverts the acknowledgement of an alarm. The optional body names the
+// user and gives a reason for the reversal.
+func (h *AlarmHandler) UnacknowledgeAlarm(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	ack, err := decodeAckRequest(r)
+	if err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	alarm, err := h.service.UnacknowledgeAlarm(auditContext(r), id, ack)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// SetSuppression shelves, suppresses or takes an alarm out of service, or returns it to service.
+func (h *AlarmHandler) SetSuppression(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	var request struct {
+		Suppression models.SuppressionState `json:"suppression"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	alarm, err := h.service.SetSuppression(auditContext(r), id, request.Suppression)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// ShelveAlarm silences an alarm for a duration or until a point in time.
+func (h *AlarmHandler) ShelveAlarm(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	var request models.ShelveRequest
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	alarm, err := h.service.ShelveAlarm(auditContext(r), id, request)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// UnshelveAlarm returns a shelved alarm to service before its shelve expires.
+func (h *AlarmHandler) UnshelveAlarm(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	alarm, err := h.service.UnshelveAlarm(auditContext(r), id)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, alarm)
+}
+
+// GetAlarmTransitions returns the states and actions an alarm can move to from its current state.
+func (h *AlarmHandler) GetAlarmTransitions(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	transitions, err := h.service.GetAlarmTransitions(id)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, transitions)
+}
+
+// GetDeliveryStatus returns the notification count and recent delivery results for an alarm.
+func (h *AlarmHandler) GetDeliveryStatus(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	status, err := h.service.GetDeliveryStatus(id)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, status)
+}
+
+// GetNextNotification returns when the next reminder for an alarm is due.
+func (h *AlarmHandler) GetNextNotification(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	schedule, err := h.service.GetNextNotification(id)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, schedule)
+}
+
+// GetNotificationStats returns the occupancy of the notification queues, including
+// the number of notifications dropped under backpressure.
+func (h *AlarmHandler) GetNotificationStats(w http.ResponseWriter, r *http.Request) {
+	h.respondWithJSON(w, http.StatusOK, h.service.NotificationStats())
+}
+
+// GetAlarmHistory returns the audit trail of an alarm, which remains available after deletion.
+func (h *AlarmHandler) GetAlarmHistory(w http.ResponseWriter, r *http.Request) {
+	id := alarmID(r)
+
+	history, err := h.service.GetAlarmHistory(id)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, history)
+}
+
 // DeleteAlarm deletes an alarm by ID and responds with a proper status and message.
 func (h *AlarmHandler) DeleteAlarm(w http.ResponseWriter, r *http.Request) {
-	id := r.URL.Query().Get("id")
+	id := alarmID(r)
 
 	if id == "" {
-		h.respondWithError(w, http.StatusBadRequest, "Alarm ID is required")
+		h.respondWithServiceError(w, &services.ValidationError{Field: "id", Message: "alarm ID is required"})
 		return
 	}
 
-	msg, err := h.service.DeleteAlarm(id)
+	msg, err := h.service.DeleteAlarm(auditContext(r), id)
 	if err != nil {
-		h.respondWithError(w, http.StatusNotFound, "Alarm not found")
+		h.respondWithServiceError(w, err)
 		return
 	}
 
@@ -110,14 +382,155 @@ func (h *AlarmHandler) DeleteAlarm(w http.ResponseWriter, r *http.Request) {
 	h.respondWithJSON(w, http.StatusOK, response)
 }
 
+// parseBulkOptions reads the `atomic` query parameter of a bulk request.
+func parseBulkOptions(r *http.Request) (services.BulkOptions, error) {
+	var opts services.BulkOptions
+	if value := r.URL.Query().Get("atomic"); value != "" {
+		atomic, err := strconv.ParseBool(value)
+		if err != nil {
+			return opts, &services.ValidationError{Field: "atomic", Message: "invalid atomic flag, expected true or false"}
+		}
+		opts.Atomic = atomic
+	}
+	return opts, nil
+}
+
+// decodeAckRequest reads the optional user and comment of an acknowledgement request.
+// An empty body yields an empty request.
+func decodeAckRequest(r *http.Request) (models.AckRequest, error) {
+	var ack models.AckRequest
+	if err := json.NewDecoder(r.Body).Decode(&ack); err != nil && !errors.Is(err, io.EOF) {
+		return ack, err
+	}
+	return ack, nil
+}
+
+// alarmID returns the ID of the alarm a request targets: the {id} path parameter of
+// the /v1 routes, or the `id` query parameter of the legacy routes.
+func alarmID(r *http.Request) string {
+	if id := r.PathValue("id"); id != "" {
+		return id
+	}
+	return r.URL.Query().Get("id")
+}
+
+// auditContext returns the context of a request carrying the audit details recorded in the
+// history of the alarms it changes: the actor named by the X-Actor header and the route
+// that served the request.
+func auditContext(r *http.Request) context.Context {
+	via := r.Pattern
+	if via == "" {
+		via = "api"
+	}
+	return services.WithAudit(r.Context(), services.Audit{Actor: r.Header.Get("X-Actor"), Via: via})
+}
+
+// parseAlarmQuery builds an alarm query from URL parameters. `state` and `severity` accept
+// comma-separated or repeated values; `label` takes a label selector such as
+// env=prod,team!=db,host=~web.* and may be repeated; `name` matches a substring;
+// `created_after`, `created_before`, `updated_after` and `updated_before` take RFC 3339
+// timestamps; `sort` names a timestamp field, prefixed with "-" for descending order;
+// `shelved` is include or only to list shelved alarms, which are left out by default;
+// `limit` and `cursor` page through the results.
+func parseAlarmQuery(values url.Values) (models.AlarmQuery, error) {
+	query := models.AlarmQuery{NameContains: values.Get("name")}
+
+	for _, state := range splitValues(values["state"]) {
+		if !models.AlarmState(state).IsValid() {
+			return models.AlarmQuery{}, &services.ValidationError{Field: "state", Message: "invalid state filter"}
+		}
+		query.States = append(query.States, models.AlarmState(state))
+	}
+	for _, severity := range splitValues(values["severity"]) {
+		if !models.Severity(severity).IsValid() {
+			return models.AlarmQuery{}, &services.ValidationError{Field: "severity", Message: "invalid severity filter"}
+		}
+		query.Severities = append(query.Severities, models.Severity(severity))
+	}
+	for _, selector := range values["label"] {
+		matchers, err := models.ParseSelector(selector)
+		if err != nil {
+			return models.AlarmQuery{}, &services.ValidationError{Field: "label", Message: err.Error()}
+		}
+		query.Selector = append(query.Selector, matchers...)
+	}
+
+	for param, target := range map[string]*time.Time{
+		"created_after":  &query.CreatedAfter,
+		"created_before": &query.CreatedBefore,
+		"updated_after":  &query.UpdatedAfter,
+		"updated_before": &query.UpdatedBefore,
+	} {
+		if value := values.Get(param); value != "" {
+			timestamp, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return models.AlarmQuery{}, &services.ValidationError{Field: param, Message: fmt.Sprintf("invalid %s, expected an RFC 3339 timestamp", param)}
+			}
+			*target = timestamp
+		}
+	}
+
+	if sort := values.Get("sort"); sort != "" {
+		query.Descending = strings.HasPrefix(sort, "-")
+		query.SortBy = models.SortField(strings.TrimPrefix(sort, "-"))
+		if !query.SortBy.IsValid() {
+			return models.AlarmQuery{}, &services.ValidationError{Field: "sort", Message: "invalid sort field"}
+		}
+	}
+	if limit := values.Get("limit"); limit != "" {
+		value, err := strconv.Atoi(limit)
+		if err != nil || value <= 0 {
+			return models.AlarmQuery{}, &services.ValidationError{Field: "limit", Message: "invalid limit"}
+		}
+		query.Limit = value
+	}
+	query.Shelved = models.ShelvedFilter(values.Get("shelved"))
+	if !query.Shelved.IsValid() {
+		return models.AlarmQuery{}, &services.ValidationError{Field: "shelved", Message: "invalid shelved filter, expected include or only"}
+	}
+	query.Cursor = values.Get("cursor")
+	return query, nil
+}
+
+// splitValues flattens repeated and comma-separated query values, dropping empty entries.
+func splitValues(values []string) []string {
+	var result []string
+	for _, value := range values {
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				result = append(result, item)
+			}
+		}
+	}
+	return result
+}
+
+// respondWithBulkResults sends a 207 Multi-Status response listing the outcome of every
+// item of a bulk request, or the problem of a request that failed as a whole.
+func (h *AlarmHandler) respondWithBulkResults(w http.ResponseWriter, successStatus int, results []services.BulkResult, err error) {
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	items := make([]BulkItemResult, 0, len(results))
+	for _, result := range results {
+		item := BulkItemResult{Index: result.Index, Status: successStatus}
+		if result.Err != nil {
+			problem := problemOf(result.Err)
+			item.Status = problem.Status
+			item.Error = &problem
+		} else {
+			item.Alarm = &result.Alarm
+		}
+		items = append(items, item)
+	}
+	h.respondWithJSON(w, http.StatusMultiStatus, items)
+}
+
 // respondWithJSON sends a JSON response with the given status code and payload.
 func (h *AlarmHandler) respondWithJSON(w http.ResponseWriter, statusCode int, payload interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
 	json.NewEncoder(w).Encode(payload)
 }
-
-// respondWithError sends an error response with the given status code and message.
-func (h *AlarmHandler) respondWithError(w http.ResponseWriter, statusCode int, message string) {
-	h.respondWithJSON(w, statusCode, map[string]string{"error": message})
-}
\ No newline at end of file
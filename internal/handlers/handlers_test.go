@@ -2,9 +2,11 @@ package handlers
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 
 	"github.com/deeprajsshetty/alarm-service/internal/models"
@@ -12,9 +14,20 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+// newService creates an AlarmService with opts, failing the test if the options are invalid.
+func newService(t *testing.T, opts ...services.Option) *services.AlarmService {
+	t.Helper()
+
+	service, err := services.NewAlarmService(opts...)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	return service
+}
+
 // TestCreateAlarm_Success tests successful alarm creation via HTTP handler.
 func TestCreateAlarm_Success(t *testing.T) {
-	service := services.NewAlarmService()
+	service := newService(t)
 	handler := NewAlarmHandler(service)
 
 	alarmPayload := `{"name": "Server Overload", "state": "Triggered"}`
@@ -33,7 +46,7 @@ func TestCreateAlarm_Success(t *testing.T) {
 
 // TestCreateAlarm_InvalidPayload tests creating an alarm with invalid payload.
 func TestCreateAlarm_InvalidPayload(t *testing.T) {
-	service := services.NewAlarmService()
+	service := newService(t)
 	handler := NewAlarmHandler(service)
 
 	invalidPayload := `{"state": "Triggered"}`
@@ -48,7 +61,7 @@ func TestCreateAlarm_InvalidPayload(t *testing.T) {
 
 // TestGetAllAlarms tests fetching all alarms.
 func TestGetAllAlarms(t *testing.T) {
-	service := services.NewAlarmService()
+	service := newService(t)
 	handler := NewAlarmHandler(service)
 
 	req := httptest.NewRequest(http.MethodGet, "/alarms", nil)
@@ -61,8 +74,8 @@ func TestGetAllAlarms(t *testing.T) {
 
 // TestGetAlarmByID_Success tests fetching an alarm by valid ID.
 func TestGetAlarmByID_Success(t *testing.T) {
-	service := services.NewAlarmService()
-	alarm, _ := service.CreateAlarm(models.Alarm{Name: "Memory Alert", State: models.Triggered})
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Memory Alert", State: models.Triggered})
 	handler := NewAlarmHandler(service)
 
 	req := httptest.NewRequest(http.MethodGet, "/alarm?id="+alarm.ID, nil)
@@ -75,7 +88,7 @@ func TestGetAlarmByID_Success(t *testing.T) {
 
 // TestGetAlarmByID_NotFound tests fetching an alarm with an invalid ID.
 func TestGetAlarmByID_NotFound(t *testing.T) {
-	service := services.NewAlarmService()
+	service := newService(t)
 	handler := NewAlarmHandler(service)
 
 	req := httptest.NewRequest(http.MethodGet, "/alarm?id=invalidID", nil)
@@ -88,8 +101,8 @@ func TestGetAlarmByID_NotFound(t *testing.T) {
 
 // TestUpdateAlarmState_Success tests successfully updating an alarm's state.
 func TestUpdateAlarmState_Success(t *testing.T) {
-	service := services.NewAlarmService()
-	alarm, _ := service.CreateAlarm(models.Alarm{Name: "Disk Space Alert", State: models.Triggered})
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered})
 	handler := NewAlarmHandler(service)
 
 	updatePayload := `{"state":"Cleared"}`
@@ -104,7 +117,7 @@ func TestUpdateAlarmState_Success(t *testing.T) {
 
 // TestUpdateAlarmState_NotFound tests updating an alarm with an invalid ID.
 func TestUpdateAlarmState_NotFound(t *testing.T) {
-	service := services.NewAlarmService()
+	service := newService(t)
 	handler := NewAlarmHandler(service)
 
 	req := httptest.NewRequest(http.MethodPut, "/alarm?id=invalidID", bytes.NewBuffer([]byte(`{"state":"Cleared"}`)))
@@ -117,8 +130,8 @@ func TestUpdateAlarmState_NotFound(t *testing.T) {
 
 // TestDeleteAlarm_Success tests successfully deleting an alarm.
 func TestDeleteAlarm_Success(t *testing.T) {
-	service := services.NewAlarmService()
-	alarm, _ := service.CreateAlarm(models.Alarm{Name: "CPU Overload", State: models.Triggered})
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "CPU Overload", State: models.Triggered})
 	handler := NewAlarmHandler(service)
 
 	req := httptest.NewRequest(http.MethodDelete, "/alarm?id="+alarm.ID, nil)
@@ -131,7 +144,7 @@ func TestDeleteAlarm_Success(t *testing.T) {
 
 // TestDeleteAlarm_NotFound tests deleting an alarm with an invalid ID.
 func TestDeleteAlarm_NotFound(t *testing.T) {
-	service := services.NewAlarmService()
+	service := newService(t)
 	handler := NewAlarmHandler(service)
 
 	req := httptest.NewRequest(http.MethodDelete, "/alarm?id=invalidID", nil)
@@ -141,3 +154,400 @@ func TestDeleteAlarm_NotFound(t *testing.T) {
 
 	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
 }
+
+// TestUpdateAlarmState_Conflict tests that a disallowed transition returns 409.
+func TestUpdateAlarmState_Conflict(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered})
+	service.UpdateAlarmState(context.Background(), alarm.ID, models.Cleared)
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPut, "/alarm?id="+alarm.ID, bytes.NewBuffer([]byte(`{"state":"Active"}`)))
+	recorder := httptest.NewRecorder()
+
+	handler.UpdateAlarmState(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Expected HTTP 409 Conflict")
+}
+
+// TestReopenAlarm_Success tests reopening a Cleared alarm.
+func TestReopenAlarm_Success(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered})
+	service.UpdateAlarmState(context.Background(), alarm.ID, models.Cleared)
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPost, "/alarm/reopen?id="+alarm.ID, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.ReopenAlarm(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var response models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, models.Triggered, response.State)
+}
+
+// TestGetAlarmTransitions tests listing the allowed transitions of an alarm.
+func TestGetAlarmTransitions(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered})
+	service.UpdateAlarmState(context.Background(), alarm.ID, models.ACKed)
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/alarm/transitions?id="+alarm.ID, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetAlarmTransitions(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var response models.AlarmTransitions
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, []models.AlarmState{models.Cleared}, response.States)
+}
+
+// TestReportCondition_Success tests reporting a condition change.
+func TestReportCondition_Success(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Tank Level High", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPost, "/alarm/condition?id="+alarm.ID, bytes.NewBuffer([]byte(`{"condition":"Normal"}`)))
+	recorder := httptest.NewRecorder()
+
+	handler.ReportCondition(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var response models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, models.ISAReturnedUnacked, response.ISAState)
+}
+
+// TestReportCondition_InvalidPayload tests reporting an unknown condition.
+func TestReportCondition_InvalidPayload(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Tank Level High", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPost, "/alarm/condition?id="+alarm.ID, bytes.NewBuffer([]byte(`{"condition":"Sideways"}`)))
+	recorder := httptest.NewRecorder()
+
+	handler.ReportCondition(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request")
+}
+
+// TestAcknowledgeAlarm_Conflict tests acknowledging an already acknowledged alarm.
+func TestAcknowledgeAlarm_Conflict(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Tank Level High", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPost, "/alarm/ack?id="+alarm.ID, nil)
+	recorder := httptest.NewRecorder()
+	handler.AcknowledgeAlarm(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+
+	recorder = httptest.NewRecorder()
+	handler.AcknowledgeAlarm(recorder, req)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Expected HTTP 409 Conflict")
+}
+
+// TestAcknowledgeAlarm_UserAndUnacknowledge tests acknowledging with a user and comment and
+// reverting the acknowledgement.
+func TestAcknowledgeAlarm_UserAndUnacknowledge(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Tank Level High", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPost, "/alarm/ack?id="+alarm.ID, bytes.NewBufferString(`{"user": "operator-1", "comment": "valve closed"}`))
+	recorder := httptest.NewRecorder()
+	handler.AcknowledgeAlarm(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarm))
+	assert.Equal(t, "operator-1", alarm.ACKedBy)
+	assert.Equal(t, "valve closed", alarm.AckComment)
+
+	req = httptest.NewRequest(http.MethodPost, "/alarm/unack?id="+alarm.ID, nil)
+	recorder = httptest.NewRecorder()
+	handler.UnacknowledgeAlarm(recorder, req)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var unacked models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &unacked))
+	assert.Equal(t, models.Triggered, unacked.State)
+	assert.Empty(t, unacked.ACKedBy)
+
+	recorder = httptest.NewRecorder()
+	handler.UnacknowledgeAlarm(recorder, req)
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Expected HTTP 409 Conflict")
+
+	req = httptest.NewRequest(http.MethodPost, "/alarm/ack?id="+alarm.ID, bytes.NewBufferString(`{"user": `))
+	recorder = httptest.NewRecorder()
+	handler.AcknowledgeAlarm(recorder, req)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request")
+}
+
+// TestSetSuppression_Success tests taking an alarm out of service.
+func TestSetSuppression_Success(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Pump Trip", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPut, "/alarm/suppression?id="+alarm.ID, bytes.NewBuffer([]byte(`{"suppression":"OutOfService"}`)))
+	recorder := httptest.NewRecorder()
+
+	handler.SetSuppression(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var response models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Equal(t, models.ISAOutOfService, response.ISAState)
+}
+
+// TestGetAllAlarms_SeverityFilter tests filtering alarms by severity.
+func TestGetAllAlarms_SeverityFilter(t *testing.T) {
+	service := newService(t)
+	service.CreateAlarm(context.Background(), models.Alarm{Name: "Datacenter Outage", State: models.Triggered, Severity: models.Critical})
+	service.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered, Severity: models.Warning})
+	service.CreateAlarm(context.Background(), models.Alarm{Name: "Build Finished", State: models.Triggered, Severity: models.Info})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/alarms?severity=Critical,Warning", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetAllAlarms(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var response []models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &response))
+	assert.Len(t, response, 2)
+}
+
+// TestGetAllAlarms_InvalidSeverity tests filtering with an unknown severity.
+func TestGetAllAlarms_InvalidSeverity(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/alarms?severity=Catastrophic", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetAllAlarms(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request")
+}
+
+// TestGetDeliveryStatus_NotFound tests fetching delivery results for an unknown alarm.
+func TestGetDeliveryStatus_NotFound(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodGet, "/alarm/deliveries?id=invalidID", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetDeliveryStatus(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
+}
+
+// TestGetNextNotification_Success tests fetching the next reminder time of an alarm.
+func TestGetNextNotification_Success(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Failure", State: models.Triggered, Severity: models.Critical})
+
+	req := httptest.NewRequest(http.MethodGet, "/alarm/schedule?id="+alarm.ID, nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetNextNotification(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var response models.NotificationSchedule
+	err := json.Unmarshal(recorder.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, alarm.ID, response.AlarmID)
+	assert.NotEmpty(t, response.NextNotificationAt, "Expected a pending reminder")
+}
+
+// TestGetAllAlarms_QueryAndPagination tests filtering by label and name with cursor pagination and label selectors.
+func TestGetAllAlarms_QueryAndPagination(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+	for _, name := range []string{"Disk A", "Disk B", "Disk C", "CPU"} {
+		service.CreateAlarm(context.Background(), models.Alarm{Name: name, State: models.Triggered, Labels: map[string]string{"team": "storage"}})
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/alarms?name=disk&label=team=storage&limit=2", nil)
+	recorder := httptest.NewRecorder()
+
+	handler.GetAllAlarms(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var firstPage []models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &firstPage))
+	assert.Len(t, firstPage, 2)
+	cursor := recorder.Header().Get("X-Next-Cursor")
+	assert.NotEmpty(t, cursor, "Expected a cursor for the next page")
+
+	req = httptest.NewRequest(http.MethodGet, "/alarms?name=disk&label=team=storage&limit=2&cursor="+cursor, nil)
+	recorder = httptest.NewRecorder()
+
+	handler.GetAllAlarms(recorder, req)
+
+	var secondPage []models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &secondPage))
+	assert.Len(t, secondPage, 1)
+	assert.Empty(t, recorder.Header().Get("X-Next-Cursor"), "Expected no cursor on the last page")
+	req = httptest.NewRequest(http.MethodGet, "/alarms?label="+url.QueryEscape("team=~stor.*,team!=db")+"&name=cpu", nil)
+	recorder = httptest.NewRecorder()
+
+	handler.GetAllAlarms(recorder, req)
+
+	var selected []models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &selected))
+	assert.Len(t, selected, 1, "Expected the label selector to match the CPU alarm")
+}
+
+// TestGetAllAlarms_InvalidQuery tests rejecting malformed query parameters.
+func TestGetAllAlarms_InvalidQuery(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+
+	for _, query := range []string{"sort=name", "limit=0", "created_after=yesterday", "label=team", "label=host=~(", "cursor=bogus"} {
+		req := httptest.NewRequest(http.MethodGet, "/alarms?"+query, nil)
+		recorder := httptest.NewRecorder()
+
+		handler.GetAllAlarms(recorder, req)
+
+		assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request for %s", query)
+	}
+}
+
+// decodeProblem asserts a problem+json response and decodes its body.
+func decodeProblem(t *testing.T, recorder *httptest.ResponseRecorder) Problem {
+	t.Helper()
+
+	assert.Equal(t, "application/problem+json", recorder.Header().Get("Content-Type"), "Expected a problem+json response")
+	var problem Problem
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &problem))
+	assert.Equal(t, recorder.Code, problem.Status, "Expected the problem status to match the response")
+	return problem
+}
+
+// TestUpdateAlarmState_InvalidState tests that an unknown state is a 400 rather than a 404.
+func TestUpdateAlarmState_InvalidState(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Memory Alert", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodPut, "/alarm?id="+alarm.ID, bytes.NewBufferString(`{"state": "Exploded"}`))
+	recorder := httptest.NewRecorder()
+
+	handler.UpdateAlarmState(recorder, req)
+
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request")
+	problem := decodeProblem(t, recorder)
+	assert.Equal(t, CodeInvalidState, problem.Code)
+	assert.Equal(t, "state", problem.Errors[0].Field)
+}
+
+// TestProblemResponses tests the status and code of not-found and conflict problems.
+func TestProblemResponses(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Memory Alert", State: models.Triggered})
+	service.UpdateAlarmState(context.Background(), alarm.ID, models.Cleared)
+	handler := NewAlarmHandler(service)
+
+	req := httptest.NewRequest(http.MethodDelete, "/alarm?id=invalidID", nil)
+	recorder := httptest.NewRecorder()
+	handler.DeleteAlarm(recorder, req)
+
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
+	assert.Equal(t, CodeNotFound, decodeProblem(t, recorder).Code)
+
+	req = httptest.NewRequest(http.MethodPost, "/alarm/ack?id="+alarm.ID, nil)
+	recorder = httptest.NewRecorder()
+	handler.AcknowledgeAlarm(recorder, req)
+
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Expected HTTP 409 Conflict")
+	assert.Equal(t, CodeConflict, decodeProblem(t, recorder).Code)
+}
+
+// TestBulkCreateAlarms_ItemResults tests that every item of a bulk creation is reported individually.
+func TestBulkCreateAlarms_ItemResults(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+
+	payload := `[{"name": "Valid", "state": "Triggered"}, {"state": "Triggered"}, {"name": "Loud", "state": "Triggered", "severity": "Deafening"}]`
+	req := httptest.NewRequest(http.MethodPost, "/alarms/bulk", bytes.NewBufferString(payload))
+	recorder := httptest.NewRecorder()
+
+	handler.BulkCreateAlarms(recorder, req)
+
+	assert.Equal(t, http.StatusMultiStatus, recorder.Code, "Expected HTTP 207 Multi-Status")
+	var results []BulkItemResult
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, http.StatusCreated, results[0].Status)
+		assert.NotEmpty(t, results[0].Alarm.ID, "Expected the created alarm ID")
+		assert.Equal(t, http.StatusBadRequest, results[1].Status)
+		assert.Equal(t, "name", results[1].Error.Errors[0].Field)
+		assert.Equal(t, 2, results[2].Index)
+		assert.Equal(t, "severity", results[2].Error.Errors[0].Field)
+	}
+	assert.Len(t, service.GetAllAlarms(), 1, "Expected the valid alarm to be created")
+}
+
+// TestBulkCreateAlarms_Atomic tests that an invalid item rolls back an atomic bulk creation.
+func TestBulkCreateAlarms_Atomic(t *testing.T) {
+	service := newService(t)
+	handler := NewAlarmHandler(service)
+
+	payload := `[{"name": "Valid", "state": "Triggered"}, {"state": "Triggered"}]`
+	req := httptest.NewRequest(http.MethodPost, "/alarms/bulk?atomic=true", bytes.NewBufferString(payload))
+	recorder := httptest.NewRecorder()
+
+	handler.BulkCreateAlarms(recorder, req)
+
+	var results []BulkItemResult
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, http.StatusFailedDependency, results[0].Status)
+		assert.Equal(t, CodeRolledBack, results[0].Error.Code)
+		assert.Equal(t, http.StatusBadRequest, results[1].Status)
+	}
+	assert.Empty(t, service.GetAllAlarms(), "Expected no alarm to be created")
+}
+
+// TestBulkUpdateAndDelete tests bulk state updates and deletions.
+func TestBulkUpdateAndDelete(t *testing.T) {
+	service := newService(t)
+	first, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "First", State: models.Triggered})
+	second, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Second", State: models.Triggered})
+	handler := NewAlarmHandler(service)
+
+	payload := `[{"id": "` + first.ID + `", "state": "ACKed"}, {"id": "` + first.ID + `", "state": "Cleared"}, {"id": "` + second.ID + `", "state": "Triggered"}]`
+	req := httptest.NewRequest(http.MethodPut, "/alarms/bulk", bytes.NewBufferString(payload))
+	recorder := httptest.NewRecorder()
+
+	handler.BulkUpdateAlarmStates(recorder, req)
+
+	var results []BulkItemResult
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	if assert.Len(t, results, 3) {
+		assert.Equal(t, http.StatusOK, results[1].Status)
+		assert.Equal(t, models.Cleared, results[1].Alarm.State)
+		assert.Equal(t, http.StatusConflict, results[2].Status)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, "/alarms/bulk", bytes.NewBufferString(`["`+first.ID+`", "missing"]`))
+	recorder = httptest.NewRecorder()
+
+	handler.BulkDeleteAlarms(recorder, req)
+
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &results))
+	if assert.Len(t, results, 2) {
+		assert.Equal(t, http.StatusOK, results[0].Status)
+		assert.Equal(t, http.StatusNotFound, results[1].Status)
+	}
+	assert.Len(t, service.GetAllAlarms(), 1, "Expected one alarm to remain")
+}
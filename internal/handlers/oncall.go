@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/services"
+)
+
+// ListUsers returns the users who can be put on call.
+func (h *AlarmHandler) ListUsers(w http.ResponseWriter, r *http.Request) {
+	h.respondWithJSON(w, http.StatusOK, h.service.ListUsers())
+}
+
+// ListSchedules returns the on-call schedules.
+func (h *AlarmHandler) ListSchedules(w http.ResponseWriter, r *http.Request) {
+	h.respondWithJSON(w, http.StatusOK, h.service.ListSchedules())
+}
+
+// GetSchedule retrieves an on-call schedule by its ID.
+func (h *AlarmHandler) GetSchedule(w http.ResponseWriter, r *http.Request) {
+	schedule, err := h.service.GetSchedule(r.PathValue("id"))
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, schedule)
+}
+
+// GetOnCall returns who is on call for a schedule now or at the RFC 3339 time given by `at`.
+func (h *AlarmHandler) GetOnCall(w http.ResponseWriter, r *http.Request) {
+	var at time.Time
+	if value := r.URL.Query().Get("at"); value != "" {
+		parsed, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			h.respondWithServiceError(w, &services.ValidationError{Field: "at", Message: "invalid at, expected an RFC 3339 timestamp"})
+			return
+		}
+		at = parsed
+	}
+
+	onCall, err := h.service.WhoIsOnCall(r.PathValue("id"), at)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, onCall)
+}
+
+// ListOverrides returns the overrides of an on-call schedule, including past ones.
+func (h *AlarmHandler) ListOverrides(w http.ResponseWriter, r *http.Request) {
+	overrides, err := h.service.ListOverrides(r.PathValue("id"))
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, overrides)
+}
+
+// CreateOverride handles the creation of an override putting a user on call for a schedule.
+func (h *AlarmHandler) CreateOverride(w http.ResponseWriter, r *http.Request) {
+	var override models.Override
+	if err := json.NewDecoder(r.Body).Decode(&override); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	createdOverride, err := h.service.CreateOverride(auditContext(r), r.PathValue("id"), override)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, createdOverride)
+}
+
+// DeleteOverride removes an override, handing the schedule back to its rotations.
+func (h *AlarmHandler) DeleteOverride(w http.ResponseWriter, r *http.Request) {
+	if err := h.service.DeleteOverride(auditContext(r), r.PathValue("id"), r.PathValue("override")); err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+
+	"github.com/deeprajsshetty/alarm-service/internal/services"
+)
+
+// problemContentType is the media type of error responses, as defined by RFC 7807.
+const problemContentType = "application/problem+json"
+
+// Machine-readable problem codes returned in the `code` member of error responses.
+const (
+	CodeInvalidPayload     = "invalid_payload"     // Request body is not valid JSON
+	CodeValidationFailed   = "validation_failed"   // One or more fields are invalid, see `errors`
+	CodeInvalidState       = "invalid_state"       // Lifecycle state does not exist
+	CodeNotFound           = "not_found"           // Alarm does not exist
+	CodeConflict           = "conflict"            // Request conflicts with the current alarm state
+	CodeRolledBack         = "rolled_back"         // Item of an atomic bulk request was not applied
+	CodeServiceUnavailable = "service_unavailable" // Service is shutting down
+	CodeInternal           = "internal_error"      // Unexpected server error
+)
+
+// Problem is an RFC 7807 problem details body, extended with a machine-readable
+// code and the individual errors of a validation failure.
+type Problem struct {
+	Type   string         `json:"type"`             // Problem type URI, "about:blank" as the code identifies the problem
+	Title  string         `json:"title"`            // Status text of the response
+	Status int            `json:"status"`           // HTTP status code of the response
+	Detail string         `json:"detail,omitempty"` // Human-readable explanation of this occurrence
+	Code   string         `json:"code"`             // Machine-readable problem code
+	Errors []ProblemError `json:"errors,omitempty"` // Invalid fields
+}
+
+// ProblemError describes a single invalid field.
+type ProblemError struct {
+	Field  string `json:"field"`  // JSON name of the invalid field
+	Code   string `json:"code"`   // Machine-readable problem code
+	Detail string `json:"detail"` // Human-readable description
+}
+
+// newProblem returns a problem with the given status code, problem code and detail.
+func newProblem(statusCode int, code, detail string) Problem {
+	return Problem{
+		Type:   "about:blank",
+		Title:  http.StatusText(statusCode),
+		Status: statusCode,
+		Detail: detail,
+		Code:   code,
+	}
+}
+
+// problemOf maps an error returned by the alarm service to a problem: missing resources to
+// 404, invalid input to 400, lifecycle conflicts to 409, rolled back bulk items to 424
+// and a closed service to 503. Any other error is an unexpected 500.
+func problemOf(err error) Problem {
+	var validationErr *services.ValidationError
+
+	switch {
+	case errors.Is(err, services.ErrNotFound):
+		return newProblem(http.StatusNotFound, CodeNotFound, err.Error())
+	case errors.As(err, &validationErr):
+		code := CodeValidationFailed
+		if errors.Is(err, services.ErrInvalidState) {
+			code = CodeInvalidState
+		}
+		problem := newProblem(http.StatusBadRequest, code, err.Error())
+		problem.Errors = []ProblemError{{Field: validationErr.Field, Code: code, Detail: err.Error()}}
+		return problem
+	case errors.Is(err, services.ErrConflict):
+		return newProblem(http.StatusConflict, CodeConflict, err.Error())
+	case errors.Is(err, services.ErrRolledBack):
+		return newProblem(http.StatusFailedDependency, CodeRolledBack, err.Error())
+	case errors.Is(err, services.ErrClosed):
+		return newProblem(http.StatusServiceUnavailable, CodeServiceUnavailable, err.Error())
+	default:
+		return newProblem(http.StatusInternalServerError, CodeInternal, "internal server error")
+	}
+}
+
+// respondWithError sends a problem+json response with the given status code, problem code and detail.
+func (h *AlarmHandler) respondWithError(w http.ResponseWriter, statusCode int, code, detail string) {
+	h.respondWithProblem(w, newProblem(statusCode, code, detail))
+}
+
+// respondWithServiceError sends the problem+json response of an error returned by the
+// alarm service, logging unexpected errors.
+func (h *AlarmHandler) respondWithServiceError(w http.ResponseWriter, err error) {
+	problem := problemOf(err)
+	if problem.Status == http.StatusInternalServerError {
+		log.Printf("unexpected service error: %v", err)
+	}
+	h.respondWithProblem(w, problem)
+}
+
+// respondWithProblem sends a problem+json response.
+func (h *AlarmHandler) respondWithProblem(w http.ResponseWriter, problem Problem) {
+	w.Header().Set("Content-Type", problemContentType)
+	w.WriteHeader(problem.Status)
+	json.NewEncoder(w).Encode(problem)
+}
@@ -0,0 +1,85 @@
+package handlers
+
+import "net/http"
+
+// NewRouter returns the HTTP handler serving the versioned /v1 API together with the
+// legacy query-parameter routes, which are kept for existing clients.
+func NewRouter(handler *AlarmHandler) http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("GET /v1/alarms", handler.ListAlarms)
+	mux.HandleFunc("POST /v1/alarms", handler.CreateAlarm)
+	mux.HandleFunc("POST /v1/alarms/bulk", handler.BulkCreateAlarms)
+	mux.HandleFunc("PUT /v1/alarms/bulk", handler.BulkUpdateAlarmStates)
+	mux.HandleFunc("DELETE /v1/alarms/bulk", handler.BulkDeleteAlarms)
+	mux.HandleFunc("GET /v1/alarms/{id}", handler.GetAlarmByID)
+	mux.HandleFunc("DELETE /v1/alarms/{id}", handler.DeleteAlarm)
+	mux.HandleFunc("PUT /v1/alarms/{id}/state", handler.UpdateAlarmState)
+	mux.HandleFunc("GET /v1/alarms/{id}/transitions", handler.GetAlarmTransitions)
+	mux.HandleFunc("POST /v1/alarms/{id}/reopen", handler.ReopenAlarm)
+	mux.HandleFunc("POST /v1/alarms/{id}/condition", handler.ReportCondition)
+	mux.HandleFunc("POST /v1/alarms/{id}/ack", handler.AcknowledgeAlarm)
+	mux.HandleFunc("POST /v1/alarms/{id}/unack", handler.UnacknowledgeAlarm)
+	mux.HandleFunc("PUT /v1/alarms/{id}/suppression", handler.SetSuppression)
+	mux.HandleFunc("POST /v1/alarms/{id}/shelve", handler.ShelveAlarm)
+	mux.HandleFunc("POST /v1/alarms/{id}/unshelve", handler.UnshelveAlarm)
+	mux.HandleFunc("GET /v1/alarms/{id}/deliveries", handler.GetDeliveryStatus)
+	mux.HandleFunc("GET /v1/alarms/{id}/schedule", handler.GetNextNotification)
+	mux.HandleFunc("GET /v1/alarms/{id}/history", handler.GetAlarmHistory)
+	mux.HandleFunc("GET /v1/notifications/stats", handler.GetNotificationStats)
+	mux.HandleFunc("GET /v1/silences", handler.ListSilences)
+	mux.HandleFunc("POST /v1/silences", handler.CreateSilence)
+	mux.HandleFunc("GET /v1/silences/{id}", handler.GetSilence)
+	mux.HandleFunc("DELETE /v1/silences/{id}", handler.ExpireSilence)
+	mux.HandleFunc("GET /v1/routes", handler.GetRoutes)
+	mux.HandleFunc("POST /v1/routes/test", handler.TestRoutes)
+	mux.HandleFunc("GET /v1/users", handler.ListUsers)
+	mux.HandleFunc("GET /v1/schedules", handler.ListSchedules)
+	mux.HandleFunc("GET /v1/schedules/{id}", handler.GetSchedule)
+	mux.HandleFunc("GET /v1/schedules/{id}/oncall", handler.GetOnCall)
+	mux.HandleFunc("GET /v1/schedules/{id}/overrides", handler.ListOverrides)
+	mux.HandleFunc("POST /v1/schedules/{id}/overrides", handler.CreateOverride)
+	mux.HandleFunc("DELETE /v1/schedules/{id}/overrides/{override}", handler.DeleteOverride)
+
+	registerLegacyRoutes(mux, handler)
+	return mux
+}
+
+// registerLegacyRoutes registers the original routes, which address single alarms with
+// an `id` query parameter. Their responses are marked deprecated in favour of /v1.
+func registerLegacyRoutes(mux *http.ServeMux, handler *AlarmHandler) {
+	legacy := map[string]http.HandlerFunc{
+		"GET /alarms":              handler.GetAllAlarms,
+		"POST /alarms/bulk":        handler.BulkCreateAlarms,
+		"PUT /alarms/bulk":         handler.BulkUpdateAlarmStates,
+		"DELETE /alarms/bulk":      handler.BulkDeleteAlarms,
+		"GET /alarm":               handler.GetAlarmByID,
+		"POST /alarm":              handler.CreateAlarm,
+		"PUT /alarm":               handler.UpdateAlarmState,
+		"DELETE /alarm":            handler.DeleteAlarm,
+		"GET /alarm/transitions":   handler.GetAlarmTransitions,
+		"POST /alarm/reopen":       handler.ReopenAlarm,
+		"POST /alarm/condition":    handler.ReportCondition,
+		"POST /alarm/ack":          handler.AcknowledgeAlarm,
+		"POST /alarm/unack":        handler.UnacknowledgeAlarm,
+		"PUT /alarm/suppression":   handler.SetSuppression,
+		"POST /alarm/shelve":       handler.ShelveAlarm,
+		"POST /alarm/unshelve":     handler.UnshelveAlarm,
+		"GET /alarm/deliveries":    handler.GetDeliveryStatus,
+		"GET /alarm/schedule":      handler.GetNextNotification,
+		"GET /alarm/history":       handler.GetAlarmHistory,
+		"GET /notifications/stats": handler.GetNotificationStats,
+	}
+	for pattern, handle := range legacy {
+		mux.Handle(pattern, deprecated(handle))
+	}
+}
+
+// deprecated marks responses of a legacy route as deprecated, pointing clients to the /v1 API.
+func deprecated(next http.HandlerFunc) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Deprecation", "true")
+		w.Header().Set("Link", `</v1/alarms>; rel="successor-version"`)
+		next(w, r)
+	})
+}
@@ -0,0 +1,235 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/services"
+	"github.com/stretchr/testify/assert"
+)
+
+// serve sends a request through the router and returns the recorded response.
+func serve(router http.Handler, method, target, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, target, bytes.NewBufferString(body))
+	req.Header.Set("Content-Type", "application/json")
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	return recorder
+}
+
+// TestRouter_V1AlarmResource tests the alarm lifecycle through the /v1 resource routes.
+func TestRouter_V1AlarmResource(t *testing.T) {
+	router := NewRouter(NewAlarmHandler(newService(t)))
+
+	recorder := serve(router, http.MethodPost, "/v1/alarms", `{"name": "Disk Failure", "state": "Triggered"}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Expected HTTP 201 Created")
+	var alarm models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarm))
+
+	recorder = serve(router, http.MethodGet, "/v1/alarms/"+alarm.ID, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.Empty(t, recorder.Header().Get("Deprecation"), "Expected /v1 routes not to be deprecated")
+
+	recorder = serve(router, http.MethodPost, "/v1/alarms/"+alarm.ID+"/ack", "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarm))
+	assert.Equal(t, models.ACKed, alarm.State)
+
+	recorder = serve(router, http.MethodGet, "/v1/alarms?state=ACKed", "")
+	var page models.AlarmPage
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Len(t, page.Alarms, 1)
+
+	recorder = serve(router, http.MethodDelete, "/v1/alarms/"+alarm.ID, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+
+	recorder = serve(router, http.MethodGet, "/v1/alarms/"+alarm.ID, "")
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
+}
+
+// TestRouter_AlarmHistory tests that changes are recorded with their actor and route and
+// remain available after deletion.
+func TestRouter_AlarmHistory(t *testing.T) {
+	router := NewRouter(NewAlarmHandler(newService(t)))
+
+	recorder := serve(router, http.MethodPost, "/v1/alarms", `{"name": "Disk Failure", "state": "Triggered"}`)
+	var alarm models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarm))
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/alarms/"+alarm.ID+"/ack", nil)
+	req.Header.Set("X-Actor", "operator-1")
+	router.ServeHTTP(httptest.NewRecorder(), req)
+	serve(router, http.MethodDelete, "/v1/alarms/"+alarm.ID, "")
+
+	recorder = serve(router, http.MethodGet, "/v1/alarms/"+alarm.ID+"/history", "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	var history models.AlarmHistory
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &history))
+	assert.True(t, history.Deleted)
+	assert.Len(t, history.Transitions, 3)
+	assert.Equal(t, "operator-1", history.Transitions[1].Actor)
+	assert.Equal(t, "POST /v1/alarms/{id}/ack", history.Transitions[1].Via)
+	assert.Equal(t, models.ReasonDeleted, history.Transitions[2].Reason)
+	assert.NotNil(t, history.TimeToAcknowledgeSeconds)
+
+	recorder = serve(router, http.MethodGet, "/v1/alarms/unknown/history", "")
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
+}
+
+// TestRouter_ShelveAlarm tests shelving, listing shelved alarms and unshelving.
+func TestRouter_ShelveAlarm(t *testing.T) {
+	router := NewRouter(NewAlarmHandler(newService(t)))
+
+	recorder := serve(router, http.MethodPost, "/v1/alarms", `{"name": "Chattering Sensor", "state": "Triggered"}`)
+	var alarm models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarm))
+
+	recorder = serve(router, http.MethodPost, "/v1/alarms/"+alarm.ID+"/shelve", `{"duration": "2h"}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request without reason")
+
+	recorder = serve(router, http.MethodPost, "/v1/alarms/"+alarm.ID+"/shelve", `{"duration": "2h", "reason": "sensor chattering"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarm))
+	assert.Equal(t, models.Shelved, alarm.Suppression)
+	assert.NotEmpty(t, alarm.ShelvedUntil)
+
+	var page models.AlarmPage
+	recorder = serve(router, http.MethodGet, "/v1/alarms", "")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Empty(t, page.Alarms, "Expected shelved alarms left out by default")
+	recorder = serve(router, http.MethodGet, "/v1/alarms?shelved=only", "")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &page))
+	assert.Len(t, page.Alarms, 1)
+	recorder = serve(router, http.MethodGet, "/v1/alarms?shelved=maybe", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request")
+
+	recorder = serve(router, http.MethodPost, "/v1/alarms/"+alarm.ID+"/unshelve", "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	recorder = serve(router, http.MethodPost, "/v1/alarms/"+alarm.ID+"/unshelve", "")
+	assert.Equal(t, http.StatusConflict, recorder.Code, "Expected HTTP 409 Conflict")
+}
+
+// TestRouter_Silences tests creating, listing and expiring silences.
+func TestRouter_Silences(t *testing.T) {
+	router := NewRouter(NewAlarmHandler(newService(t)))
+
+	recorder := serve(router, http.MethodPost, "/v1/silences", `{"matchers": [{"name": "team", "value": "storage"}]}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request without ends_at")
+
+	req := httptest.NewRequest(http.MethodPost, "/v1/silences", bytes.NewBufferString(`{
+		"matchers": [{"name": "team", "value": "storage"}],
+		"recurrence": {"weekdays": ["Sunday"], "start_time": "02:00", "end_time": "04:00", "time_zone": "Europe/Berlin"},
+		"comment": "weekly storage maintenance"
+	}`))
+	req.Header.Set("X-Actor", "operator-1")
+	recorder = httptest.NewRecorder()
+	router.ServeHTTP(recorder, req)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Expected HTTP 201 Created")
+	var silence models.Silence
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &silence))
+	assert.Equal(t, "operator-1", silence.CreatedBy)
+	assert.Equal(t, models.SilenceActive, silence.Status)
+
+	recorder = serve(router, http.MethodGet, "/v1/silences/"+silence.ID, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+
+	recorder = serve(router, http.MethodDelete, "/v1/silences/"+silence.ID, "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &silence))
+	assert.Equal(t, models.SilenceExpired, silence.Status)
+
+	var silences []models.Silence
+	recorder = serve(router, http.MethodGet, "/v1/silences", "")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &silences))
+	assert.Len(t, silences, 1)
+
+	recorder = serve(router, http.MethodGet, "/v1/silences/unknown", "")
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
+}
+
+// TestRouter_OnCall tests the on-call schedule, override and who-is-on-call routes.
+func TestRouter_OnCall(t *testing.T) {
+	router := NewRouter(NewAlarmHandler(newService(t, services.WithOnCall(models.OnCallConfig{
+		Users: []models.User{{ID: "alice", Notifiers: []string{"console"}}, {ID: "bob", Notifiers: []string{"console"}}},
+		Schedules: []models.Schedule{{ID: "database", Layers: []models.Rotation{
+			{Name: "weeks", Users: []string{"alice", "bob"}, Handoff: models.HandoffWeekly, HandoffDay: "Monday", HandoffTime: "09:00", Start: "2024-01-01T09:00:00Z"},
+		}}},
+	}))))
+
+	var onCall models.OnCall
+	recorder := serve(router, http.MethodGet, "/v1/schedules/database/oncall?at=2024-01-08T10:00:00Z", "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &onCall))
+	assert.Equal(t, "bob", onCall.User.ID)
+	assert.Equal(t, "weeks", onCall.Layer)
+
+	recorder = serve(router, http.MethodGet, "/v1/schedules/database/oncall?at=tomorrow", "")
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request for an invalid time")
+	recorder = serve(router, http.MethodGet, "/v1/schedules/unknown/oncall", "")
+	assert.Equal(t, http.StatusNotFound, recorder.Code, "Expected HTTP 404 Not Found")
+
+	recorder = serve(router, http.MethodPost, "/v1/schedules/database/overrides", `{"user_id": "alice", "starts_at": "2024-01-08T00:00:00Z", "ends_at": "2024-01-09T00:00:00Z"}`)
+	assert.Equal(t, http.StatusCreated, recorder.Code, "Expected HTTP 201 Created")
+	var override models.Override
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &override))
+
+	recorder = serve(router, http.MethodGet, "/v1/schedules/database/oncall?at=2024-01-08T10:00:00Z", "")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &onCall))
+	assert.Equal(t, "alice", onCall.User.ID)
+	assert.Equal(t, override.ID, onCall.OverrideID)
+
+	recorder = serve(router, http.MethodDelete, "/v1/schedules/database/overrides/"+override.ID, "")
+	assert.Equal(t, http.StatusNoContent, recorder.Code, "Expected HTTP 204 No Content")
+
+	var users []models.User
+	recorder = serve(router, http.MethodGet, "/v1/users", "")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &users))
+	assert.Len(t, users, 2)
+}
+
+// TestRouter_Routes tests the routing tree and dry-run routes.
+func TestRouter_Routes(t *testing.T) {
+	router := NewRouter(NewAlarmHandler(newService(t, services.WithRoutes(models.Route{
+		Receivers: []string{"console"},
+		Routes:    []models.Route{{Name: "critical", Matchers: []models.Matcher{{Name: models.MatchSeverity, Value: "Critical"}}, Receivers: []string{"webhook"}}},
+	}))))
+
+	var root models.Route
+	recorder := serve(router, http.MethodGet, "/v1/routes", "")
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &root))
+	assert.Len(t, root.Routes, 1)
+
+	var result models.RoutingResult
+	recorder = serve(router, http.MethodPost, "/v1/routes/test", `{"name": "Datacenter Outage", "state": "Triggered", "severity": "Critical"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &result))
+	assert.Equal(t, []string{"webhook"}, result.Receivers)
+
+	recorder = serve(router, http.MethodPost, "/v1/routes/test", `{"state": "Triggered"}`)
+	assert.Equal(t, http.StatusBadRequest, recorder.Code, "Expected HTTP 400 Bad Request without a name")
+}
+
+// TestRouter_LegacyRoutes tests that the query-parameter routes keep working and are marked deprecated.
+func TestRouter_LegacyRoutes(t *testing.T) {
+	service := newService(t)
+	alarm, _ := service.CreateAlarm(context.Background(), models.Alarm{Name: "Memory Alert", State: models.Triggered})
+	router := NewRouter(NewAlarmHandler(service))
+
+	recorder := serve(router, http.MethodPut, "/alarm?id="+alarm.ID, `{"state": "ACKed"}`)
+	assert.Equal(t, http.StatusOK, recorder.Code, "Expected HTTP 200 OK")
+	assert.Equal(t, "true", recorder.Header().Get("Deprecation"), "Expected legacy route to be deprecated")
+
+	recorder = serve(router, http.MethodGet, "/alarms", "")
+	var alarms []models.Alarm
+	assert.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &alarms))
+	assert.Len(t, alarms, 1)
+
+	recorder = serve(router, http.MethodPatch, "/alarm?id="+alarm.ID, "")
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code, "Expected HTTP 405 Method Not Allowed")
+}
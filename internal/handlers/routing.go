@@ -0,0 +1,31 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// GetRoutes returns the notification routing tree.
+func (h *AlarmHandler) GetRoutes(w http.ResponseWriter, r *http.Request) {
+	h.respondWithJSON(w, http.StatusOK, h.service.Routes())
+}
+
+// TestRoutes reports the routes a sample alarm matches and the receivers its notifications
+// would reach, without creating the alarm.
+func (h *AlarmHandler) TestRoutes(w http.ResponseWriter, r *http.Request) {
+	var alarm models.Alarm
+	if err := json.NewDecoder(r.Body).Decode(&alarm); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	result, err := h.service.RouteAlarm(alarm)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, result)
+}
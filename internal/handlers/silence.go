@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// CreateSilence handles the creation of a silence suppressing the notifications of matching alarms.
+func (h *AlarmHandler) CreateSilence(w http.ResponseWriter, r *http.Request) {
+	var silence models.Silence
+	if err := json.NewDecoder(r.Body).Decode(&silence); err != nil {
+		h.respondWithError(w, http.StatusBadRequest, CodeInvalidPayload, "Invalid request payload")
+		return
+	}
+
+	createdSilence, err := h.service.CreateSilence(auditContext(r), silence)
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusCreated, createdSilence)
+}
+
+// ListSilences returns every silence, including expired ones.
+func (h *AlarmHandler) ListSilences(w http.ResponseWriter, r *http.Request) {
+	h.respondWithJSON(w, http.StatusOK, h.service.ListSilences())
+}
+
+// GetSilence retrieves a silence by its ID.
+func (h *AlarmHandler) GetSilence(w http.ResponseWriter, r *http.Request) {
+	silence, err := h.service.GetSilence(r.PathValue("id"))
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, silence)
+}
+
+// ExpireSilence ends a silence immediately.
+func (h *AlarmHandler) ExpireSilence(w http.ResponseWriter, r *http.Request) {
+	silence, err := h.service.ExpireSilence(auditContext(r), r.PathValue("id"))
+	if err != nil {
+		h.respondWithServiceError(w, err)
+		return
+	}
+
+	h.respondWithJSON(w, http.StatusOK, silence)
+}
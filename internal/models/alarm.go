@@ -1,5 +1,12 @@
 package models
 
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"slices"
+	"strings"
+)
+
 // AlarmState represents the possible states of an alarm.
 type AlarmState string
 
@@ -10,14 +17,174 @@ const (
 	Cleared   AlarmState = "Cleared"
 )
 
+// Severity represents how urgently an alarm needs attention.
+type Severity string
+
+const (
+	Critical Severity = "Critical"
+	Major    Severity = "Major"
+	Minor    Severity = "Minor"
+	Warning  Severity = "Warning"
+	Info     Severity = "Info"
+)
+
+// AlarmCondition represents the process condition reported by the alarm source.
+type AlarmCondition string
+
+const (
+	ConditionActive AlarmCondition = "Active" // Process is in the abnormal condition
+	ConditionNormal AlarmCondition = "Normal" // Process has returned to normal
+)
+
+// AckState represents whether an operator has acknowledged the alarm.
+type AckState string
+
+const (
+	Unacknowledged AckState = "Unacked"
+	Acknowledged   AckState = "Acked"
+)
+
+// SuppressionState represents an ISA-18.2 state in which the alarm is not presented to operators.
+type SuppressionState string
+
+const (
+	NotSuppressed      SuppressionState = ""
+	Shelved            SuppressionState = "Shelved"            // Temporarily silenced by an operator
+	SuppressedByDesign SuppressionState = "SuppressedByDesign" // Suppressed by plant logic
+	OutOfService       SuppressionState = "OutOfService"       // Removed from service, e.g. for maintenance
+)
+
+// ISAState represents the combined ISA-18.2 alarm state derived from the
+// condition, acknowledgement and suppression of an alarm.
+type ISAState string
+
+const (
+	ISANormal             ISAState = "NORM"  // Condition normal, acknowledged
+	ISAUnacknowledged     ISAState = "UNACK" // Condition active, unacknowledged
+	ISAAcknowledged       ISAState = "ACKED" // Condition active, acknowledged
+	ISAReturnedUnacked    ISAState = "RTNUN" // Returned to normal, unacknowledged
+	ISAShelved            ISAState = "SHLVD" // Shelved
+	ISASuppressedByDesign ISAState = "DSUPR" // Suppressed by design
+	ISAOutOfService       ISAState = "OOSRV" // Out of service
+)
+
 // Alarm represents the structure for an alarm with essential details.
 type Alarm struct {
-	ID        string     `json:"id"`        // Unique identifier for the alarm
-	Name      string     `json:"name"`      // Descriptive name of the alarm
-	State     AlarmState `json:"state"`     // Current state of the alarm
-	CreatedAt string     `json:"created_at"` // Creation timestamp of the alarm
-	UpdatedAt string     `json:"updated_at"` // Last updated timestamp of the alarm
-	ACKedAt   string     `json:"acked_at"`   // Timestamp for when the alarm was acknowledged
+	ID               string            `json:"id"`                          // Unique identifier for the alarm
+	Name             string            `json:"name"`                        // Descriptive name of the alarm
+	State            AlarmState        `json:"state"`                       // Current lifecycle state of the alarm
+	Severity         Severity          `json:"severity"`                    // Urgency of the alarm, defaults to Minor
+	Condition        AlarmCondition    `json:"condition"`                   // Process condition reported by the source
+	Acknowledgement  AckState          `json:"ack_state"`                   // Operator acknowledgement of the alarm
+	Suppression      SuppressionState  `json:"suppression,omitempty"`       // Shelved, suppressed-by-design or out-of-service
+	ShelvedUntil     string            `json:"shelved_until,omitempty"`     // When a shelved alarm is automatically unshelved
+	ShelveReason     string            `json:"shelve_reason,omitempty"`     // Why the alarm was shelved
+	ShelvedBy        string            `json:"shelved_by,omitempty"`        // User who shelved the alarm
+	InhibitedBy      []string          `json:"inhibited_by,omitempty"`      // IDs of open alarms withholding the notifications of this one
+	EscalationPolicy string            `json:"escalation_policy,omitempty"` // Name of the escalation policy assigned to the alarm
+	EscalationLevel  int               `json:"escalation_level,omitempty"`  // Tier the alarm has escalated to, counted from zero across repeats
+	EscalateAt       string            `json:"escalate_at,omitempty"`       // When the alarm escalates to the next tier unless acknowledged
+	ISAState         ISAState          `json:"isa_state"`                   // Combined ISA-18.2 state, derived from the fields above
+	Labels           map[string]string `json:"labels,omitempty"`            // Identifying key/value pairs, indexed and used for matching
+	Annotations      map[string]string `json:"annotations,omitempty"`       // Descriptive key/value pairs such as a summary or runbook URL
+	Source           string            `json:"source,omitempty"`            // Monitoring system or host that raised the alarm
+	DedupKey         string            `json:"dedup_key,omitempty"`         // Identifies repeats of the alarm, see Fingerprint
+	Occurrences      int               `json:"occurrences"`                 // Number of times the alarm was raised
+	LastSeen         string            `json:"last_seen"`                   // Timestamp of the latest occurrence
+	CreatedAt        string            `json:"created_at"`                  // Creation timestamp of the alarm
+	UpdatedAt        string            `json:"updated_at"`                  // Last updated timestamp of the alarm
+	ClearedAt        string            `json:"cleared_at,omitempty"`        // Timestamp of when the alarm was cleared, while it is Cleared
+	ACKedAt          string            `json:"acked_at"`                    // Timestamp for when the alarm was acknowledged
+	ACKedBy          string            `json:"acked_by,omitempty"`          // User who acknowledged the alarm
+	AckComment       string            `json:"ack_comment,omitempty"`       // Comment left with the acknowledgement
+}
+
+// CombinedState derives the ISA-18.2 state of the alarm. Suppression takes
+// precedence over the condition and acknowledgement dimensions.
+func (a Alarm) CombinedState() ISAState {
+	switch a.Suppression {
+	case Shelved:
+		return ISAShelved
+	case SuppressedByDesign:
+		return ISASuppressedByDesign
+	case OutOfService:
+		return ISAOutOfService
+	}
+
+	acked := a.Acknowledgement == Acknowledged
+	switch {
+	case a.Condition == ConditionNormal && acked:
+		return ISANormal
+	case a.Condition == ConditionNormal:
+		return ISAReturnedUnacked
+	case acked:
+		return ISAAcknowledged
+	default:
+		return ISAUnacknowledged
+	}
+}
+
+// Fingerprint returns the key identifying repeats of the alarm: the explicit DedupKey if
+// set, otherwise a hash of the name, source and labels. Alarms with neither a DedupKey
+// nor a Source have no fingerprint and are never deduplicated.
+func (a Alarm) Fingerprint() string {
+	if a.DedupKey != "" {
+		return a.DedupKey
+	}
+	if a.Source == "" {
+		return ""
+	}
+
+	parts := []string{a.Name, a.Source}
+	keys := make([]string, 0, len(a.Labels))
+	for key := range a.Labels {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		parts = append(parts, key+"="+a.Labels[key])
+	}
+	sum := sha256.Sum256([]byte(strings.Join(parts, "\x00")))
+	return hex.EncodeToString(sum[:16])
+}
+
+// severityRanks orders severities from least to most urgent.
+var severityRanks = map[Severity]int{
+	Info:     1,
+	Warning:  2,
+	Minor:    3,
+	Major:    4,
+	Critical: 5,
+}
+
+// MoreSevereThan reports whether s is more urgent than other.
+func (s Severity) MoreSevereThan(other Severity) bool {
+	return severityRanks[s] > severityRanks[other]
+}
+
+// IsValid checks if the provided severity is valid.
+func (s Severity) IsValid() bool {
+	switch s {
+	case Critical, Major, Minor, Warning, Info:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsValid checks if the provided alarm condition is valid.
+func (c AlarmCondition) IsValid() bool {
+	return c == ConditionActive || c == ConditionNormal
+}
+
+// IsValid checks if the provided suppression state is valid.
+func (s SuppressionState) IsValid() bool {
+	switch s {
+	case NotSuppressed, Shelved, SuppressedByDesign, OutOfService:
+		return true
+	default:
+		return false
+	}
 }
 
 // IsValid checks if the provided alarm state is valid.
@@ -29,3 +196,70 @@ func (a AlarmState) IsValid() bool {
 		return false
 	}
 }
+
+// AlarmAction represents a dedicated lifecycle action that is not a regular state transition.
+type AlarmAction string
+
+const (
+	Reopen        AlarmAction = "reopen"        // Moves a Cleared alarm back to Triggered
+	Unacknowledge AlarmAction = "unacknowledge" // Reverts the acknowledgement of an ACKed alarm
+)
+
+// stateTransitions defines the states each alarm state may move to via a regular update.
+var stateTransitions = map[AlarmState][]AlarmState{
+	Triggered: {Active, ACKed, Cleared},
+	Active:    {ACKed, Cleared},
+	ACKed:     {Cleared},
+	Cleared:   {},
+}
+
+// stateActions defines the dedicated actions available from each alarm state.
+var stateActions = map[AlarmState][]AlarmAction{
+	ACKed:   {Unacknowledge},
+	Cleared: {Reopen},
+}
+
+// AlarmTransitions describes the valid next steps for an alarm in its current state.
+type AlarmTransitions struct {
+	State   AlarmState    `json:"state"`           // Current state of the alarm
+	States  []AlarmState  `json:"allowed_states"`  // States reachable via a regular update
+	Actions []AlarmAction `json:"allowed_actions"` // Dedicated actions available
+}
+
+// CanTransitionTo reports whether an alarm in state a may be updated to next.
+func (a AlarmState) CanTransitionTo(next AlarmState) bool {
+	for _, allowed := range stateTransitions[a] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Transitions returns the states and actions available from state a.
+func (a AlarmState) Transitions() AlarmTransitions {
+	return AlarmTransitions{
+		State:   a,
+		States:  append([]AlarmState{}, stateTransitions[a]...),
+		Actions: append([]AlarmAction{}, stateActions[a]...),
+	}
+}
+
+// AckRequest identifies who acknowledges an alarm, or reverts an acknowledgement, and why.
+type AckRequest struct {
+	User    string `json:"user"`    // User acknowledging the alarm, defaults to the request actor
+	Comment string `json:"comment"` // Optional free-text comment
+}
+
+// ShelveRequest shelves an alarm for a duration or until a point in time.
+type ShelveRequest struct {
+	Duration string `json:"duration,omitempty"` // How long to shelve the alarm for, as a Go duration such as "30m"
+	Until    string `json:"until,omitempty"`    // RFC 3339 time to shelve the alarm until, instead of a duration
+	Reason   string `json:"reason"`             // Why the alarm is shelved
+}
+
+// StateUpdate is an item of a bulk state update.
+type StateUpdate struct {
+	ID    string     `json:"id"`    // ID of the alarm to update
+	State AlarmState `json:"state"` // Lifecycle state to move the alarm to
+}
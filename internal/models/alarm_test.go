@@ -19,4 +19,77 @@ func TestIsValid_ValidStates(t *testing.T) {
 func TestIsValid_InvalidState(t *testing.T) {
 	invalidState := AlarmState("InvalidState")
 	assert.False(t, invalidState.IsValid(), "Expected invalid state to return false")
-}
\ No newline at end of file
+}
+
+// TestCanTransitionTo verifies the lifecycle transition table.
+func TestCanTransitionTo(t *testing.T) {
+	assert.True(t, Triggered.CanTransitionTo(Active))
+	assert.True(t, Triggered.CanTransitionTo(ACKed))
+	assert.True(t, Active.CanTransitionTo(Cleared))
+	assert.True(t, ACKed.CanTransitionTo(Cleared))
+
+	assert.False(t, ACKed.CanTransitionTo(Active), "Expected ACKed to not move back to Active")
+	assert.False(t, Cleared.CanTransitionTo(Triggered), "Expected Cleared to reopen only via action")
+	assert.False(t, Triggered.CanTransitionTo(Triggered), "Expected self transitions to be rejected")
+}
+
+// TestTransitions verifies allowed states and actions are exposed per state.
+func TestTransitions(t *testing.T) {
+	transitions := Cleared.Transitions()
+	assert.Equal(t, Cleared, transitions.State)
+	assert.Empty(t, transitions.States)
+	assert.Equal(t, []AlarmAction{Reopen}, transitions.Actions)
+
+	transitions = ACKed.Transitions()
+	assert.Equal(t, []AlarmState{Cleared}, transitions.States)
+	assert.Equal(t, []AlarmAction{Unacknowledge}, transitions.Actions)
+}
+
+// TestCombinedState verifies the ISA-18.2 state derived from condition, acknowledgement and suppression.
+func TestCombinedState(t *testing.T) {
+	tests := []struct {
+		alarm    Alarm
+		expected ISAState
+	}{
+		{Alarm{Condition: ConditionActive, Acknowledgement: Unacknowledged}, ISAUnacknowledged},
+		{Alarm{Condition: ConditionActive, Acknowledgement: Acknowledged}, ISAAcknowledged},
+		{Alarm{Condition: ConditionNormal, Acknowledgement: Unacknowledged}, ISAReturnedUnacked},
+		{Alarm{Condition: ConditionNormal, Acknowledgement: Acknowledged}, ISANormal},
+		{Alarm{Condition: ConditionActive, Suppression: Shelved}, ISAShelved},
+		{Alarm{Condition: ConditionActive, Suppression: SuppressedByDesign}, ISASuppressedByDesign},
+		{Alarm{Condition: ConditionNormal, Suppression: OutOfService}, ISAOutOfService},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, tt.alarm.CombinedState(), "Unexpected combined state for %+v", tt.alarm)
+	}
+}
+
+// TestSeverityIsValid tests valid and invalid severities.
+func TestSeverityIsValid(t *testing.T) {
+	for _, severity := range []Severity{Critical, Major, Minor, Warning, Info} {
+		assert.True(t, severity.IsValid(), "Expected severity %v to be valid", severity)
+	}
+	assert.False(t, Severity("Catastrophic").IsValid(), "Expected unknown severity to be invalid")
+	assert.False(t, Severity("").IsValid(), "Expected empty severity to be invalid")
+}
+
+// TestFingerprint verifies dedup keys are explicit or derived from name, source and labels.
+func TestFingerprint(t *testing.T) {
+	alarm := Alarm{Name: "Disk Space Alert", Source: "host-1", Labels: map[string]string{"mount": "/var", "team": "storage"}}
+	reordered := Alarm{Name: "Disk Space Alert", Source: "host-1", Labels: map[string]string{"team": "storage", "mount": "/var"}}
+
+	assert.NotEmpty(t, alarm.Fingerprint())
+	assert.Equal(t, alarm.Fingerprint(), reordered.Fingerprint(), "Expected label order to be irrelevant")
+	assert.NotEqual(t, alarm.Fingerprint(), Alarm{Name: "Disk Space Alert", Source: "host-2"}.Fingerprint())
+	assert.Equal(t, "custom", Alarm{Name: "Disk Space Alert", Source: "host-1", DedupKey: "custom"}.Fingerprint())
+	assert.Empty(t, Alarm{Name: "Disk Space Alert"}.Fingerprint(), "Expected no fingerprint without source or dedup key")
+}
+
+// TestMoreSevereThan verifies the severity ordering.
+func TestMoreSevereThan(t *testing.T) {
+	assert.True(t, Critical.MoreSevereThan(Major))
+	assert.True(t, Warning.MoreSevereThan(Info))
+	assert.False(t, Minor.MoreSevereThan(Minor))
+	assert.False(t, Info.MoreSevereThan(Critical))
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// maxEscalationRepeat bounds how often the tiers of an escalation policy are repeated.
+const maxEscalationRepeat = 10
+
+// EscalationTier is a step of an escalation policy: the targets paged at this tier and how
+// long they have to acknowledge the alarm before it escalates to the next tier.
+type EscalationTier struct {
+	Targets []string `json:"targets"` // Names of the notifiers paged at this tier
+	Wait    string   `json:"wait"`    // Go duration before escalating, such as "15m"
+}
+
+// EscalationPolicy escalates unacknowledged alarms through ordered tiers of targets.
+type EscalationPolicy struct {
+	Name     string           `json:"name"`
+	Matchers []Matcher        `json:"matchers,omitempty"` // Alarms the policy is assigned to; all alarms when empty
+	Tiers    []EscalationTier `json:"tiers"`
+	Repeat   int              `json:"repeat,omitempty"` // Times the tiers are run again after the last one
+}
+
+// Validate checks the name, matchers, tiers and repeat count of the policy.
+func (p EscalationPolicy) Validate() error {
+	if p.Name == "" {
+		return errors.New("escalation policy name is mandatory")
+	}
+	for _, matcher := range p.Matchers {
+		if err := matcher.Validate(); err != nil {
+			return fmt.Errorf("escalation policy %q: %w", p.Name, err)
+		}
+	}
+	if len(p.Tiers) == 0 {
+		return fmt.Errorf("escalation policy %q needs at least one tier", p.Name)
+	}
+	for i, tier := range p.Tiers {
+		if len(tier.Targets) == 0 {
+			return fmt.Errorf("tier %d of escalation policy %q has no targets", i+1, p.Name)
+		}
+		if wait, err := time.ParseDuration(tier.Wait); err != nil || wait <= 0 {
+			return fmt.Errorf("tier %d of escalation policy %q has an invalid wait %q", i+1, p.Name, tier.Wait)
+		}
+	}
+	if p.Repeat < 0 || p.Repeat > maxEscalationRepeat {
+		return fmt.Errorf("escalation policy %q must repeat between 0 and %d times", p.Name, maxEscalationRepeat)
+	}
+	return nil
+}
+
+// ValidateEscalationPolicies checks every policy and that no two policies share a name.
+func ValidateEscalationPolicies(policies []EscalationPolicy) error {
+	names := make(map[string]bool, len(policies))
+	for _, policy := range policies {
+		if err := policy.Validate(); err != nil {
+			return err
+		}
+		if names[policy.Name] {
+			return fmt.Errorf("duplicate escalation policy %q", policy.Name)
+		}
+		names[policy.Name] = true
+	}
+	return nil
+}
+
+// Steps returns the number of tiers an alarm passes through, counting repeats.
+func (p EscalationPolicy) Steps() int {
+	return len(p.Tiers) * (p.Repeat + 1)
+}
+
+// Tier returns the tier of the given escalation level, counted from zero across repeats,
+// or an empty tier if the policy has none.
+func (p EscalationPolicy) Tier(level int) EscalationTier {
+	if len(p.Tiers) == 0 || level < 0 {
+		return EscalationTier{}
+	}
+	return p.Tiers[level%len(p.Tiers)]
+}
+
+// WaitDuration returns the parsed wait of the tier, or zero if it is invalid.
+func (t EscalationTier) WaitDuration() time.Duration {
+	wait, _ := time.ParseDuration(t.Wait)
+	return wait
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestEscalationPolicy tests policy validation and how tiers repeat across escalation levels.
+func TestEscalationPolicy(t *testing.T) {
+	policy := EscalationPolicy{
+		Name: "database",
+		Tiers: []EscalationTier{
+			{Targets: []string{"webhook"}, Wait: "5m"},
+			{Targets: []string{"email"}, Wait: "15m"},
+		},
+		Repeat: 2,
+	}
+
+	assert.NoError(t, policy.Validate())
+	assert.Equal(t, 6, policy.Steps())
+	assert.Equal(t, []string{"webhook"}, policy.Tier(2).Targets)
+	assert.Equal(t, 15*time.Minute, policy.Tier(3).WaitDuration())
+
+	for _, invalid := range []EscalationPolicy{
+		{Tiers: policy.Tiers},
+		{Name: "empty"},
+		{Name: "no-targets", Tiers: []EscalationTier{{Wait: "5m"}}},
+		{Name: "no-wait", Tiers: []EscalationTier{{Targets: []string{"webhook"}, Wait: "0s"}}},
+		{Name: "repeat", Tiers: policy.Tiers, Repeat: maxEscalationRepeat + 1},
+		{Name: "matcher", Tiers: policy.Tiers, Matchers: []Matcher{{Name: "team", Value: "(", IsRegex: true}}},
+	} {
+		assert.Error(t, invalid.Validate(), "Expected policy %q to be invalid", invalid.Name)
+	}
+
+	assert.Empty(t, EscalationPolicy{Name: "empty"}.Tier(1).Targets, "Expected a policy without tiers to have empty tiers")
+	assert.NoError(t, ValidateEscalationPolicies([]EscalationPolicy{policy}))
+	assert.Error(t, ValidateEscalationPolicies([]EscalationPolicy{policy, policy}), "Expected duplicate policy names to be rejected")
+	assert.Error(t, ValidateEscalationPolicies([]EscalationPolicy{{Name: "empty"}}))
+}
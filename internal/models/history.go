@@ -0,0 +1,24 @@
+package models
+
+// Transition is an entry of the audit trail of an alarm, recorded for every change of its
+// lifecycle or combined state and retained after the alarm is deleted.
+type Transition struct {
+	AlarmID  string             `json:"alarm_id"`            // ID of the alarm that changed
+	At       string             `json:"at"`                  // Timestamp of the change
+	From     AlarmState         `json:"from,omitempty"`      // Lifecycle state before the change, empty on creation
+	To       AlarmState         `json:"to,omitempty"`        // Lifecycle state after the change, empty on deletion
+	ISAState ISAState           `json:"isa_state,omitempty"` // Combined ISA-18.2 state after the change, empty on deletion
+	Reason   NotificationReason `json:"reason"`              // What caused the change
+	Actor    string             `json:"actor,omitempty"`     // Who made the change, if known
+	Comment  string             `json:"comment,omitempty"`   // Free-text comment supplied with the change
+	Via      string             `json:"via"`                 // API route or component that made the change
+}
+
+// AlarmHistory is the audit trail of an alarm together with the response times derived from it.
+type AlarmHistory struct {
+	AlarmID                  string       `json:"alarm_id"`
+	Deleted                  bool         `json:"deleted"`                               // Whether the alarm has been deleted
+	Transitions              []Transition `json:"transitions"`                           // Changes in the order they were made
+	TimeToAcknowledgeSeconds *int64       `json:"time_to_acknowledge_seconds,omitempty"` // From creation to first acknowledgement
+	TimeToClearSeconds       *int64       `json:"time_to_clear_seconds,omitempty"`       // From creation to first clear
+}
@@ -0,0 +1,48 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+)
+
+// InhibitRule withholds the notifications of target alarms while a source alarm is Triggered
+// or Active, such as downstream latency alarms while the core switch they depend on is down.
+type InhibitRule struct {
+	Name           string    `json:"name,omitempty"`
+	SourceMatchers []Matcher `json:"source_matchers"` // Alarms that inhibit others while Triggered or Active
+	TargetMatchers []Matcher `json:"target_matchers"` // Alarms that are inhibited
+	Equal          []string  `json:"equal,omitempty"` // Fields or labels source and target must share
+}
+
+// Validate checks that the rule has valid source and target matchers.
+func (r InhibitRule) Validate() error {
+	if len(r.SourceMatchers) == 0 || len(r.TargetMatchers) == 0 {
+		return errors.New("inhibit rules need source and target matchers")
+	}
+	for _, matcher := range append(append([]Matcher{}, r.SourceMatchers...), r.TargetMatchers...) {
+		if err := matcher.Validate(); err != nil {
+			return fmt.Errorf("inhibit rule %q: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// IsSource reports whether an alarm matches the source matchers of the rule.
+func (r InhibitRule) IsSource(alarm Alarm) bool {
+	return MatchAll(r.SourceMatchers, alarm)
+}
+
+// Inhibits reports whether the rule lets source inhibit target: source and target are
+// different alarms matching the source and target matchers and have the same value for
+// every name in Equal. A label neither alarm carries is equal.
+func (r InhibitRule) Inhibits(source, target Alarm) bool {
+	if source.ID == target.ID || !r.IsSource(source) || !MatchAll(r.TargetMatchers, target) {
+		return false
+	}
+	for _, name := range r.Equal {
+		if fieldValue(source, name) != fieldValue(target, name) {
+			return false
+		}
+	}
+	return true
+}
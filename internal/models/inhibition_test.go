@@ -0,0 +1,27 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestInhibitRule tests source and target matching and the equal-label constraint.
+func TestInhibitRule(t *testing.T) {
+	rule := InhibitRule{
+		SourceMatchers: []Matcher{{Name: MatchAlarmName, Value: "Core Switch Down"}},
+		TargetMatchers: []Matcher{{Name: MatchAlarmName, Value: "Network Latency|Service Unreachable", IsRegex: true}},
+		Equal:          []string{"site"},
+	}
+	source := Alarm{ID: "a1", Name: "Core Switch Down", Labels: map[string]string{"site": "fra"}}
+	target := Alarm{ID: "a2", Name: "Network Latency", Labels: map[string]string{"site": "fra"}}
+
+	assert.NoError(t, rule.Validate())
+	assert.True(t, rule.Inhibits(source, target))
+	assert.False(t, rule.Inhibits(target, source))
+	assert.False(t, rule.Inhibits(source, Alarm{ID: "a3", Name: "Network Latency", Labels: map[string]string{"site": "ams"}}), "Expected different sites not to inhibit")
+	assert.False(t, rule.Inhibits(source, source), "Expected an alarm not to inhibit itself")
+
+	assert.Error(t, InhibitRule{SourceMatchers: rule.SourceMatchers}.Validate())
+	assert.Error(t, InhibitRule{SourceMatchers: rule.SourceMatchers, TargetMatchers: []Matcher{{Name: "site", Value: "(", IsRegex: true}}}.Validate())
+}
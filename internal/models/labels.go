@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Limits on the labels and annotations of an alarm.
+const (
+	maxLabels           = 32
+	maxLabelValueLength = 256
+	maxAnnotations      = 32
+	maxAnnotationLength = 4096
+	maxKeyLength        = 128
+)
+
+// keyPattern is the syntax of label and annotation keys.
+var keyPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// ValidateLabels checks the number, key syntax and value length of alarm labels. Label
+// values must not be empty and the reserved matcher names cannot be used as keys.
+func ValidateLabels(labels map[string]string) error {
+	if len(labels) > maxLabels {
+		return fmt.Errorf("at most %d labels are allowed", maxLabels)
+	}
+	for key, value := range labels {
+		if err := validateKey(key); err != nil {
+			return err
+		}
+		if isReservedName(key) {
+			return fmt.Errorf("label name %q is reserved", key)
+		}
+		if value == "" || len(value) > maxLabelValueLength {
+			return fmt.Errorf("value of label %q must have 1 to %d bytes", key, maxLabelValueLength)
+		}
+	}
+	return nil
+}
+
+// ValidateAnnotations checks the number, key syntax and value length of alarm annotations.
+func ValidateAnnotations(annotations map[string]string) error {
+	if len(annotations) > maxAnnotations {
+		return fmt.Errorf("at most %d annotations are allowed", maxAnnotations)
+	}
+	for key, value := range annotations {
+		if err := validateKey(key); err != nil {
+			return err
+		}
+		if len(value) > maxAnnotationLength {
+			return fmt.Errorf("value of annotation %q exceeds %d bytes", key, maxAnnotationLength)
+		}
+	}
+	return nil
+}
+
+// validateKey checks the syntax and length of a label or annotation key.
+func validateKey(key string) error {
+	if len(key) > maxKeyLength || !keyPattern.MatchString(key) {
+		return fmt.Errorf("invalid key %q, expected letters, digits and underscores not starting with a digit", key)
+	}
+	return nil
+}
+
+// ParseSelector parses a comma-separated label selector such as env=prod,team!=db,host=~web.*
+// into matchers that an alarm must all satisfy. Each term is a name, one of the operators
+// =, !=, =~ and !~, and a value that may be double-quoted. Commas inside a quoted value or
+// inside braces, brackets or parentheses, as in host=~web{1,3}, do not end a term.
+func ParseSelector(selector string) ([]Matcher, error) {
+	terms, err := splitSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	matchers := make([]Matcher, 0, len(terms))
+	for _, term := range terms {
+		matcher, err := parseMatcher(term)
+		if err != nil {
+			return nil, err
+		}
+		if strings.HasPrefix(matcher.Value, `"`) {
+			value, err := strconv.Unquote(matcher.Value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid quoted value %s for %s", matcher.Value, matcher.Name)
+			}
+			matcher.Value = value
+		}
+		if err := matcher.Validate(); err != nil {
+			return nil, err
+		}
+		matchers = append(matchers, matcher)
+	}
+	return matchers, nil
+}
+
+// splitSelector splits a label selector into its terms at the commas outside double quotes,
+// braces, brackets and parentheses. A backslash escapes the character after it.
+func splitSelector(selector string) ([]string, error) {
+	var terms []string
+	var quoted, escaped bool
+	depth, start := 0, 0
+	for i, r := range selector {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '{' || r == '[' || r == '(':
+			depth++
+		case (r == '}' || r == ']' || r == ')') && depth > 0:
+			depth--
+		case r == ',' && depth == 0:
+			terms = append(terms, selector[start:i])
+			start = i + 1
+		}
+	}
+	if quoted {
+		return nil, fmt.Errorf("unterminated quoted value in label selector %q", selector)
+	}
+	return append(terms, selector[start:]), nil
+}
+
+// parseMatcher parses a single term of a label selector.
+func parseMatcher(term string) (Matcher, error) {
+	term = strings.TrimSpace(term)
+	i := strings.IndexAny(term, "=!")
+	if i <= 0 {
+		return Matcher{}, fmt.Errorf("invalid label selector term %q, expected a name followed by =, !=, =~ or !~", term)
+	}
+
+	matcher := Matcher{Name: strings.TrimSpace(term[:i])}
+	if !isReservedName(matcher.Name) && validateKey(matcher.Name) != nil {
+		return Matcher{}, fmt.Errorf("invalid label name %q", matcher.Name)
+	}
+	rest := term[i:]
+	var op string
+	for _, candidate := range []string{"=~", "!~", "!=", "="} {
+		if strings.HasPrefix(rest, candidate) {
+			op = candidate
+			break
+		}
+	}
+	switch op {
+	case "=~":
+		matcher.IsRegex = true
+	case "!~":
+		matcher.IsRegex, matcher.IsNegative = true, true
+	case "!=":
+		matcher.IsNegative = true
+	case "":
+		return Matcher{}, fmt.Errorf("invalid operator in label selector term %q", term)
+	}
+	matcher.Value = strings.TrimSpace(rest[len(op):])
+	return matcher, nil
+}
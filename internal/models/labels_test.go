@@ -0,0 +1,57 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestParseSelector tests parsing each selector operator, quoted values and regular expressions containing
+// commas, and that malformed terms are rejected.
+func TestParseSelector(t *testing.T) {
+	matchers, err := ParseSelector(`env=prod, team!=db,host=~web.*,zone!~"eu-.*",tier=~web{1,3}`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Matcher{
+		{Name: "env", Value: "prod"},
+		{Name: "team", Value: "db", IsNegative: true},
+		{Name: "host", Value: "web.*", IsRegex: true},
+		{Name: "zone", Value: "eu-.*", IsRegex: true, IsNegative: true},
+		{Name: "tier", Value: "web{1,3}", IsRegex: true},
+	}, matchers)
+	assert.Equal(t, "team!=db", matchers[1].String())
+
+	alarm := Alarm{Name: "Latency", Labels: map[string]string{"env": "prod", "team": "web", "host": "web-1", "tier": "webb"}}
+	assert.True(t, MatchAll(matchers, alarm))
+
+	matchers, err = ParseSelector(`zone="eu,west",host=~(web|db),path=~a\,b,team=db`)
+	assert.NoError(t, err)
+	assert.Equal(t, []Matcher{
+		{Name: "zone", Value: "eu,west"},
+		{Name: "host", Value: "(web|db)", IsRegex: true},
+		{Name: "path", Value: `a\,b`, IsRegex: true},
+		{Name: "team", Value: "db"},
+	}, matchers, "Expected commas inside quotes, groups and escapes to stay in the value")
+
+	for _, selector := range []string{"", "env", "=prod", "1env=prod", "host=~(", `env="prod`, "host=~web.*,oops", "env=prod,", `zone="eu,west`} {
+		_, err := ParseSelector(selector)
+		assert.Error(t, err, "Expected %q to be rejected", selector)
+	}
+}
+
+// TestValidateLabels tests the key syntax and size limits of labels and annotations.
+func TestValidateLabels(t *testing.T) {
+	assert.NoError(t, ValidateLabels(map[string]string{"env": "prod", "host_name": "web-1"}))
+	assert.Error(t, ValidateLabels(map[string]string{"host-name": "web-1"}))
+	assert.Error(t, ValidateLabels(map[string]string{"env": ""}))
+	assert.Error(t, ValidateLabels(map[string]string{"severity": "high"}), "Expected reserved names to be rejected")
+	assert.Error(t, ValidateLabels(map[string]string{"env": strings.Repeat("x", maxLabelValueLength+1)}))
+
+	assert.NoError(t, ValidateAnnotations(map[string]string{"runbook_url": "https://runbooks.example.com/disk", "summary": ""}))
+	assert.Error(t, ValidateAnnotations(map[string]string{"summary": strings.Repeat("x", maxAnnotationLength+1)}))
+	tooMany := make(map[string]string)
+	for i := 0; i <= maxAnnotations; i++ {
+		tooMany["note_"+strings.Repeat("x", i)] = "x"
+	}
+	assert.Error(t, ValidateAnnotations(tooMany))
+}
@@ -0,0 +1,195 @@
+package models
+
+import (
+	"container/list"
+	"errors"
+	"fmt"
+	"regexp"
+	"sync"
+)
+
+// Reserved matcher names select alarm fields; any other name selects the label of that name.
+const (
+	MatchAlarmName = "alarmname"
+	MatchSeverity  = "severity"
+	MatchState     = "state"
+	MatchSource    = "source"
+)
+
+// Matcher selects alarms whose field or label equals a value or, for regex matchers,
+// matches a regular expression anchored at both ends. Negative matchers select the
+// alarms that do not.
+type Matcher struct {
+	Name       string `json:"name"`                  // alarmname, severity, state, source or a label name
+	Value      string `json:"value"`                 // Value or regular expression to match
+	IsRegex    bool   `json:"is_regex,omitempty"`    // Treat Value as a regular expression
+	IsNegative bool   `json:"is_negative,omitempty"` // Select alarms whose value does not match
+}
+
+// maxCachedRegexes bounds the number of compiled expressions kept in regexCache.
+const maxCachedRegexes = 1024
+
+// regexCache holds the most recently used compiled expressions of regex matchers. Patterns
+// come from clients, so the cache evicts the least recently used expression once full.
+var regexCache = newRegexLRU(maxCachedRegexes)
+
+// regexLRU is a least recently used cache of compiled expressions keyed by pattern.
+type regexLRU struct {
+	capacity int
+
+	lock    sync.Mutex
+	order   *list.List               // Cached patterns, most recently used first
+	entries map[string]*list.Element // Pattern to its element in order
+}
+
+// regexEntry is an element of regexLRU.order.
+type regexEntry struct {
+	pattern string
+	re      *regexp.Regexp
+}
+
+// newRegexLRU initializes an empty cache holding at most capacity expressions.
+func newRegexLRU(capacity int) *regexLRU {
+	return &regexLRU{capacity: capacity, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the cached expression of pattern and marks it as most recently used.
+func (c *regexLRU) get(pattern string) (*regexp.Regexp, bool) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	element, ok := c.entries[pattern]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(*regexEntry).re, true
+}
+
+// put caches the expression of pattern, evicting the least recently used one when full.
+func (c *regexLRU) put(pattern string, re *regexp.Regexp) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if element, ok := c.entries[pattern]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	if c.order.Len() >= c.capacity {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*regexEntry).pattern)
+	}
+	c.entries[pattern] = c.order.PushFront(&regexEntry{pattern: pattern, re: re})
+}
+
+// len returns the number of cached expressions.
+func (c *regexLRU) len() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.order.Len()
+}
+
+// Validate checks that the matcher has a name and, for regex matchers, a valid expression.
+func (m Matcher) Validate() error {
+	if m.Name == "" {
+		return errors.New("matcher name is mandatory")
+	}
+	if m.IsRegex {
+		if _, err := m.regex(); err != nil {
+			return fmt.Errorf("invalid regular expression %q for %s", m.Value, m.Name)
+		}
+	}
+	return nil
+}
+
+// Matches reports whether an alarm satisfies the matcher. A label the alarm does not carry
+// has the empty value.
+func (m Matcher) Matches(alarm Alarm) bool {
+	return m.MatchesValue(fieldValue(alarm, m.Name))
+}
+
+// MatchesValue reports whether the value of the field or label named by the matcher satisfies it.
+// A regex matcher with an invalid expression matches nothing, whether negative or not.
+func (m Matcher) MatchesValue(value string) bool {
+	matched := value == m.Value
+	if m.IsRegex {
+		re, err := m.regex()
+		if err != nil {
+			return false
+		}
+		matched = re.MatchString(value)
+	}
+	return matched != m.IsNegative
+}
+
+// IsLabel reports whether the matcher selects a label rather than an alarm field.
+func (m Matcher) IsLabel() bool {
+	return !isReservedName(m.Name)
+}
+
+// String returns the matcher in label selector syntax, such as team!=db or host=~web.*.
+func (m Matcher) String() string {
+	var op string
+	switch {
+	case m.IsRegex && m.IsNegative:
+		op = "!~"
+	case m.IsRegex:
+		op = "=~"
+	case m.IsNegative:
+		op = "!="
+	default:
+		op = "="
+	}
+	return m.Name + op + m.Value
+}
+
+// regex returns the compiled, anchored expression of a regex matcher.
+func (m Matcher) regex() (*regexp.Regexp, error) {
+	if cached, ok := regexCache.get(m.Value); ok {
+		return cached, nil
+	}
+	re, err := regexp.Compile("^(?:" + m.Value + ")$")
+	if err != nil {
+		return nil, err
+	}
+	regexCache.put(m.Value, re)
+	return re, nil
+}
+
+// isReservedName reports whether name selects an alarm field rather than a label.
+func isReservedName(name string) bool {
+	switch name {
+	case MatchAlarmName, MatchSeverity, MatchState, MatchSource:
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldValue returns the alarm field selected by a reserved matcher name or the label of that name.
+func fieldValue(alarm Alarm, name string) string {
+	switch name {
+	case MatchAlarmName:
+		return alarm.Name
+	case MatchSeverity:
+		return string(alarm.Severity)
+	case MatchState:
+		return string(alarm.State)
+	case MatchSource:
+		return alarm.Source
+	default:
+		return alarm.Labels[name]
+	}
+}
+
+// MatchAll reports whether an alarm satisfies every matcher.
+func MatchAll(matchers []Matcher, alarm Alarm) bool {
+	for _, matcher := range matchers {
+		if !matcher.Matches(alarm) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,68 @@
+package models
+
+// NotificationReason describes why a notification was emitted for an alarm.
+type NotificationReason string
+
+const (
+	ReasonCreated            NotificationReason = "created"             // Alarm was created
+	ReasonStateChanged       NotificationReason = "state_changed"       // Lifecycle state was updated
+	ReasonReopened           NotificationReason = "reopened"            // Cleared alarm was reopened
+	ReasonConditionChanged   NotificationReason = "condition_changed"   // Source reported a condition change
+	ReasonAcknowledged       NotificationReason = "acknowledged"        // Operator acknowledged the alarm
+	ReasonUnacknowledged     NotificationReason = "unacknowledged"      // Operator reverted an acknowledgement
+	ReasonSuppressionChanged NotificationReason = "suppression_changed" // Alarm was shelved, suppressed or returned to service
+	ReasonShelved            NotificationReason = "shelved"             // Operator shelved the alarm for a bounded time
+	ReasonUnshelved          NotificationReason = "unshelved"           // Shelve expired or was lifted by an operator
+	ReasonReminder           NotificationReason = "reminder"            // Scheduled reminder became due
+	ReasonSeverityEscalated  NotificationReason = "severity_escalated"  // Source re-sent the alarm with a higher severity
+	ReasonEscalated          NotificationReason = "escalated"           // Alarm was not acknowledged in time and escalated to the next tier
+	ReasonInhibited          NotificationReason = "inhibited"           // Alarm was inhibited by an open source alarm, recorded in its history only
+	ReasonInhibitionReleased NotificationReason = "inhibition_released" // Every source alarm inhibiting the alarm cleared
+	ReasonDeleted            NotificationReason = "deleted"             // Alarm was deleted, recorded in its history only
+)
+
+// Notification is the payload delivered to notifiers for an alarm.
+type Notification struct {
+	Alarm         Alarm              `json:"alarm"`                    // Alarm snapshot at the time of the notification
+	PreviousState AlarmState         `json:"previous_state,omitempty"` // Lifecycle state before the change, if any
+	Reason        NotificationReason `json:"reason"`                   // Why the notification was emitted
+	Count         int                `json:"notification_count"`       // Number of notifications sent for the alarm, including this one
+	Recipients    []User             `json:"recipients,omitempty"`     // On-call users paged by the notification, resolved from schedule targets
+}
+
+// DeliveryResult records the outcome of delivering a notification through one notifier.
+type DeliveryResult struct {
+	Notifier string             `json:"notifier"`        // Name of the notifier
+	Reason   NotificationReason `json:"reason"`          // Reason of the delivered notification
+	Success  bool               `json:"success"`         // Whether the delivery succeeded
+	Error    string             `json:"error,omitempty"` // Delivery error, if any
+	SentAt   string             `json:"sent_at"`         // Timestamp of the delivery attempt
+}
+
+// DeliveryStatus summarises the notifications delivered for an alarm.
+type DeliveryStatus struct {
+	AlarmID           string           `json:"alarm_id"`           // ID of the alarm
+	NotificationCount int              `json:"notification_count"` // Number of notifications processed
+	Results           []DeliveryResult `json:"results"`            // Most recent delivery results, oldest first
+}
+
+// NotificationSchedule reports when the next reminder for an alarm is due.
+type NotificationSchedule struct {
+	AlarmID            string `json:"alarm_id"`                       // ID of the alarm
+	NextNotificationAt string `json:"next_notification_at,omitempty"` // Due time of the next reminder, empty when none is pending
+}
+
+// QueueStats reports the occupancy of a notification queue.
+type QueueStats struct {
+	Name     string `json:"name"`     // Name of the queue: "outbox" or the notifier name
+	Pending  int    `json:"pending"`  // Notifications waiting to be processed
+	Capacity int    `json:"capacity"` // Maximum number of pending notifications
+	Enqueued uint64 `json:"enqueued"` // Notifications accepted since startup
+	Dropped  uint64 `json:"dropped"`  // Notifications dropped because the queue was full
+}
+
+// NotificationStats reports backpressure in the notification pipeline.
+type NotificationStats struct {
+	Outbox    QueueStats   `json:"outbox"`    // Notifications queued by alarm mutations
+	Notifiers []QueueStats `json:"notifiers"` // Deliveries queued per notifier
+}
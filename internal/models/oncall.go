@@ -0,0 +1,262 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ScheduleTargetPrefix marks an escalation target that names an on-call schedule rather than
+// a notifier, such as "schedule:database". It is resolved to whoever is on call when notifying.
+const ScheduleTargetPrefix = "schedule:"
+
+// HandoffInterval is how often a rotation hands over to the next user.
+type HandoffInterval string
+
+const (
+	HandoffDaily  HandoffInterval = "daily"
+	HandoffWeekly HandoffInterval = "weekly"
+)
+
+// User is a person who can be put on call.
+type User struct {
+	ID        string   `json:"id"`
+	Name      string   `json:"name"`
+	Email     string   `json:"email,omitempty"` // Address the email notifier pages the user at
+	Notifiers []string `json:"notifiers"`       // Names of the notifiers that page the user
+}
+
+// Rotation is a layer of an on-call schedule that hands over between its users in turn.
+type Rotation struct {
+	Name        string          `json:"name"`
+	Users       []string        `json:"users"`                 // IDs of the users taking turns, in order
+	Handoff     HandoffInterval `json:"handoff"`               // daily or weekly
+	HandoffTime string          `json:"handoff_time"`          // Local time of the handoff as "15:04"
+	HandoffDay  string          `json:"handoff_day,omitempty"` // Weekday of a weekly handoff, such as "Monday"
+	TimeZone    string          `json:"time_zone,omitempty"`   // IANA time zone name, defaults to UTC
+	Start       string          `json:"start"`                 // RFC 3339 start of the first user's shift
+	Active      *Recurrence     `json:"active,omitempty"`      // Restricts the layer to recurring windows, such as nights
+}
+
+// Schedule is a set of rotation layers deciding who is on call. Later layers take precedence
+// over earlier ones where they have someone on call, and overrides over every layer.
+type Schedule struct {
+	ID     string     `json:"id"`
+	Name   string     `json:"name"`
+	Layers []Rotation `json:"layers"`
+}
+
+// Override temporarily puts a user on call for a schedule in place of its rotations.
+type Override struct {
+	ID         string `json:"id"`
+	ScheduleID string `json:"schedule_id"`
+	UserID     string `json:"user_id"`    // User on call during the override
+	StartsAt   string `json:"starts_at"`  // RFC 3339 start, defaults to creation time
+	EndsAt     string `json:"ends_at"`    // RFC 3339 end
+	CreatedBy  string `json:"created_by"` // Who created the override
+	CreatedAt  string `json:"created_at"` // RFC 3339 creation time with sub-second precision
+}
+
+// OnCall reports who is on call for a schedule at a given time.
+type OnCall struct {
+	ScheduleID string `json:"schedule_id"`
+	At         string `json:"at"`                    // RFC 3339 time the schedule was evaluated at
+	User       *User  `json:"user,omitempty"`        // User on call, absent when nobody is
+	Layer      string `json:"layer,omitempty"`       // Name of the rotation the user is on call through
+	OverrideID string `json:"override_id,omitempty"` // ID of the override the user is on call through
+}
+
+// OnCallConfig holds the users and schedules of the on-call subsystem.
+type OnCallConfig struct {
+	Users     []User     `json:"users"`
+	Schedules []Schedule `json:"schedules"`
+}
+
+// Validate checks the users and schedules of the configuration and that rotations only
+// name configured users.
+func (c OnCallConfig) Validate() error {
+	users := make(map[string]bool)
+	for _, user := range c.Users {
+		if user.ID == "" {
+			return errors.New("user id is mandatory")
+		}
+		if users[user.ID] {
+			return fmt.Errorf("duplicate user %q", user.ID)
+		}
+		if len(user.Notifiers) == 0 {
+			return fmt.Errorf("user %q has no notifiers", user.ID)
+		}
+		users[user.ID] = true
+	}
+
+	schedules := make(map[string]bool)
+	for _, schedule := range c.Schedules {
+		if schedule.ID == "" {
+			return errors.New("schedule id is mandatory")
+		}
+		if schedules[schedule.ID] {
+			return fmt.Errorf("duplicate schedule %q", schedule.ID)
+		}
+		schedules[schedule.ID] = true
+		if len(schedule.Layers) == 0 {
+			return fmt.Errorf("schedule %q needs at least one layer", schedule.ID)
+		}
+		for _, layer := range schedule.Layers {
+			if err := layer.Validate(); err != nil {
+				return fmt.Errorf("schedule %q: %w", schedule.ID, err)
+			}
+			for _, id := range layer.Users {
+				if !users[id] {
+					return fmt.Errorf("schedule %q: unknown user %q in layer %q", schedule.ID, id, layer.Name)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the users, handoff and start of the rotation.
+func (r Rotation) Validate() error {
+	if r.Name == "" {
+		return errors.New("rotation name is mandatory")
+	}
+	if len(r.Users) == 0 {
+		return fmt.Errorf("rotation %q needs at least one user", r.Name)
+	}
+	switch r.Handoff {
+	case HandoffDaily:
+	case HandoffWeekly:
+		if _, ok := parseWeekday(r.HandoffDay); !ok {
+			return fmt.Errorf("rotation %q has an invalid handoff_day %q", r.Name, r.HandoffDay)
+		}
+	default:
+		return fmt.Errorf("rotation %q has an invalid handoff %q, expected daily or weekly", r.Name, r.Handoff)
+	}
+	if _, err := time.Parse("15:04", r.HandoffTime); err != nil {
+		return fmt.Errorf("rotation %q handoff_time must be formatted as 15:04", r.Name)
+	}
+	if _, err := (Recurrence{TimeZone: r.TimeZone}).location(); err != nil {
+		return fmt.Errorf("rotation %q has an unknown time zone %q", r.Name, r.TimeZone)
+	}
+	if _, err := time.Parse(time.RFC3339, r.Start); err != nil {
+		return fmt.Errorf("rotation %q start must be an RFC 3339 timestamp", r.Name)
+	}
+	if r.Active != nil {
+		if err := r.Active.Validate(); err != nil {
+			return fmt.Errorf("rotation %q: %w", r.Name, err)
+		}
+	}
+	return nil
+}
+
+// OnCallAt returns the ID of the user on call through the rotation at t, or false before the
+// rotation starts and outside its active windows. The shift in progress at the start belongs
+// to the first user, and each handoff passes on to the next user in turn.
+func (r Rotation) OnCallAt(t time.Time) (string, bool) {
+	start, err := time.Parse(time.RFC3339, r.Start)
+	if err != nil || t.Before(start) || len(r.Users) == 0 {
+		return "", false
+	}
+	if r.Active != nil && !r.Active.Contains(t) {
+		return "", false
+	}
+	first, ok := r.lastHandoff(start)
+	if !ok {
+		return "", false
+	}
+	current, _ := r.lastHandoff(t)
+
+	// Count calendar days rather than elapsed hours so that DST changes do not shift handoffs.
+	days := int(civilDate(current).Sub(civilDate(first)).Hours()) / 24
+	shifts := days
+	if r.Handoff == HandoffWeekly {
+		shifts = days / 7
+	}
+	return r.Users[shifts%len(r.Users)], true
+}
+
+// lastHandoff returns the latest handoff of the rotation at or before t.
+func (r Rotation) lastHandoff(t time.Time) (time.Time, bool) {
+	loc, err := (Recurrence{TimeZone: r.TimeZone}).location()
+	if err != nil {
+		return time.Time{}, false
+	}
+	at, err := time.Parse("15:04", r.HandoffTime)
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	local := t.In(loc)
+	offset := 0
+	if r.Handoff == HandoffWeekly {
+		weekday, ok := parseWeekday(r.HandoffDay)
+		if !ok {
+			return time.Time{}, false
+		}
+		offset = -((int(local.Weekday()) - int(weekday) + 7) % 7)
+	}
+	handoff := time.Date(local.Year(), local.Month(), local.Day()+offset, at.Hour(), at.Minute(), 0, 0, loc)
+	if handoff.After(t) {
+		if r.Handoff == HandoffWeekly {
+			handoff = handoff.AddDate(0, 0, -7)
+		} else {
+			handoff = handoff.AddDate(0, 0, -1)
+		}
+	}
+	return handoff, true
+}
+
+// civilDate returns the calendar date of t in its location as midnight UTC.
+func civilDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// OnCallAt returns who is on call for the schedule at t. The latest created override in
+// effect at t wins, the greatest ID breaking ties; otherwise the last layer with someone
+// on call does. The returned User only carries its ID, which the caller resolves to the
+// configured user.
+func (s Schedule) OnCallAt(t time.Time, overrides []Override) OnCall {
+	onCall := OnCall{ScheduleID: s.ID, At: t.Format(time.RFC3339)}
+
+	var latest *Override
+	for i, override := range overrides {
+		if override.ScheduleID != s.ID || !override.ActiveAt(t) {
+			continue
+		}
+		if latest == nil || override.createdAfter(*latest) {
+			latest = &overrides[i]
+		}
+	}
+	if latest != nil {
+		onCall.User = &User{ID: latest.UserID}
+		onCall.OverrideID = latest.ID
+		return onCall
+	}
+
+	for i := len(s.Layers) - 1; i >= 0; i-- {
+		if id, ok := s.Layers[i].OnCallAt(t); ok {
+			onCall.User = &User{ID: id}
+			onCall.Layer = s.Layers[i].Name
+			return onCall
+		}
+	}
+	return onCall
+}
+
+// createdAfter reports whether the override was created after other, comparing creation
+// times at full precision and then IDs, so that the latest override is picked deterministically.
+func (o Override) createdAfter(other Override) bool {
+	created, _ := time.Parse(time.RFC3339Nano, o.CreatedAt)
+	otherCreated, _ := time.Parse(time.RFC3339Nano, other.CreatedAt)
+	if !created.Equal(otherCreated) {
+		return created.After(otherCreated)
+	}
+	return o.ID > other.ID
+}
+
+// ActiveAt reports whether the override is in effect at t.
+func (o Override) ActiveAt(t time.Time) bool {
+	startsAt, startErr := time.Parse(time.RFC3339, o.StartsAt)
+	endsAt, endErr := time.Parse(time.RFC3339, o.EndsAt)
+	return startErr == nil && endErr == nil && !t.Before(startsAt) && t.Before(endsAt)
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// onCallAt returns the ID of the user on call through a rotation at t, or "" if nobody is.
+func onCallAt(rotation Rotation, t time.Time) string {
+	id, _ := rotation.OnCallAt(t)
+	return id
+}
+
+// TestRotationOnCallAt tests daily and weekly handoffs in a time zone, including across a DST change.
+func TestRotationOnCallAt(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	daily := Rotation{
+		Name:        "daily",
+		Users:       []string{"alice", "bob", "carol"},
+		Handoff:     HandoffDaily,
+		HandoffTime: "09:00",
+		TimeZone:    "Europe/Berlin",
+		Start:       "2024-01-01T09:00:00+01:00",
+	}
+	assert.NoError(t, daily.Validate())
+
+	assert.Equal(t, "", onCallAt(daily, time.Date(2024, 1, 1, 8, 0, 0, 0, berlin)), "Expected nobody on call before the start")
+	assert.Equal(t, "alice", onCallAt(daily, time.Date(2024, 1, 1, 10, 0, 0, 0, berlin)))
+	assert.Equal(t, "alice", onCallAt(daily, time.Date(2024, 1, 2, 8, 59, 0, 0, berlin)))
+	assert.Equal(t, "bob", onCallAt(daily, time.Date(2024, 1, 2, 8, 0, 0, 0, time.UTC)), "Expected handoff at 09:00 in Berlin")
+	assert.Equal(t, "alice", onCallAt(daily, time.Date(2024, 1, 4, 9, 0, 0, 0, berlin)))
+
+	daily.Start = "2024-03-30T09:00:00+01:00"
+	assert.Equal(t, "bob", onCallAt(daily, time.Date(2024, 3, 31, 9, 0, 0, 0, berlin)), "Expected handoff at local time after the DST change")
+	assert.Equal(t, "alice", onCallAt(daily, time.Date(2024, 3, 31, 8, 30, 0, 0, berlin)))
+
+	weekly := Rotation{
+		Name:        "weekly",
+		Users:       []string{"alice", "bob"},
+		Handoff:     HandoffWeekly,
+		HandoffTime: "09:00",
+		HandoffDay:  "Monday",
+		Start:       "2024-01-03T12:00:00Z",
+	}
+	assert.NoError(t, weekly.Validate())
+	assert.Equal(t, "alice", onCallAt(weekly, time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "bob", onCallAt(weekly, time.Date(2024, 1, 8, 9, 0, 0, 0, time.UTC)))
+	assert.Equal(t, "alice", onCallAt(weekly, time.Date(2024, 1, 15, 9, 0, 0, 0, time.UTC)))
+
+	assert.Error(t, Rotation{Name: "r", Users: []string{"alice"}, Handoff: "hourly", HandoffTime: "09:00", Start: weekly.Start}.Validate())
+	assert.Error(t, Rotation{Name: "r", Users: []string{"alice"}, Handoff: HandoffWeekly, HandoffTime: "09:00", Start: weekly.Start}.Validate())
+	assert.Error(t, Rotation{Name: "r", Users: []string{"alice"}, Handoff: HandoffDaily, HandoffTime: "9am", Start: weekly.Start}.Validate())
+	assert.Error(t, Rotation{Name: "r", Handoff: HandoffDaily, HandoffTime: "09:00", Start: weekly.Start}.Validate())
+}
+
+// TestScheduleOnCallAt tests that later layers and overrides take precedence.
+func TestScheduleOnCallAt(t *testing.T) {
+	schedule := Schedule{
+		ID: "database",
+		Layers: []Rotation{
+			{Name: "days", Users: []string{"alice", "bob"}, Handoff: HandoffDaily, HandoffTime: "09:00", Start: "2024-01-01T09:00:00Z"},
+			{Name: "nights", Users: []string{"carol"}, Handoff: HandoffWeekly, HandoffTime: "09:00", HandoffDay: "Monday", Start: "2024-01-01T09:00:00Z",
+				Active: &Recurrence{StartTime: "18:00", EndTime: "09:00"}},
+		},
+	}
+	override := Override{ID: "o1", ScheduleID: "database", UserID: "dave", StartsAt: "2024-01-02T12:00:00Z", EndsAt: "2024-01-02T14:00:00Z"}
+
+	onCall := schedule.OnCallAt(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC), []Override{override})
+	assert.Equal(t, "bob", onCall.User.ID)
+	assert.Equal(t, "days", onCall.Layer)
+
+	onCall = schedule.OnCallAt(time.Date(2024, 1, 2, 20, 0, 0, 0, time.UTC), []Override{override})
+	assert.Equal(t, "carol", onCall.User.ID, "Expected the night layer to take precedence within its window")
+
+	onCall = schedule.OnCallAt(time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC), []Override{override})
+	assert.Equal(t, "dave", onCall.User.ID)
+	assert.Equal(t, "o1", onCall.OverrideID)
+
+	override.CreatedAt = "2024-01-02T11:00:00.5Z"
+	later := Override{ID: "o0", ScheduleID: "database", UserID: "erin", StartsAt: override.StartsAt, EndsAt: override.EndsAt, CreatedAt: "2024-01-02T11:00:00.7Z"}
+	tied := Override{ID: "o2", ScheduleID: "database", UserID: "frank", StartsAt: override.StartsAt, EndsAt: override.EndsAt, CreatedAt: override.CreatedAt}
+	at := time.Date(2024, 1, 2, 13, 0, 0, 0, time.UTC)
+	assert.Equal(t, "o0", schedule.OnCallAt(at, []Override{override, later}).OverrideID, "Expected sub-second creation times to be compared")
+	assert.Equal(t, "o2", schedule.OnCallAt(at, []Override{tied, override}).OverrideID, "Expected the greatest ID to break ties")
+	assert.Equal(t, "o2", schedule.OnCallAt(at, []Override{override, tied}).OverrideID)
+
+	assert.Nil(t, schedule.OnCallAt(time.Date(2023, 12, 31, 0, 0, 0, 0, time.UTC), nil).User)
+}
+
+// TestOnCallConfigValidate tests that schedules only reference configured users.
+func TestOnCallConfigValidate(t *testing.T) {
+	config := OnCallConfig{
+		Users: []User{{ID: "alice", Notifiers: []string{"email"}}},
+		Schedules: []Schedule{{ID: "database", Layers: []Rotation{
+			{Name: "days", Users: []string{"alice"}, Handoff: HandoffDaily, HandoffTime: "09:00", Start: "2024-01-01T09:00:00Z"},
+		}}},
+	}
+	assert.NoError(t, config.Validate())
+
+	config.Schedules[0].Layers[0].Users = []string{"alice", "bob"}
+	assert.Error(t, config.Validate())
+	assert.Error(t, OnCallConfig{Users: []User{{ID: "alice"}}}.Validate(), "Expected users without notifiers to be rejected")
+}
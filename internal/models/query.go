@@ -0,0 +1,130 @@
+package models
+
+import (
+	"slices"
+	"strings"
+	"time"
+)
+
+// SortField is an alarm timestamp that alarm listings can be ordered by.
+type SortField string
+
+const (
+	SortByCreatedAt SortField = "created_at"
+	SortByUpdatedAt SortField = "updated_at"
+	SortByACKedAt   SortField = "acked_at"
+)
+
+// IsValid checks if the provided sort field is valid.
+func (f SortField) IsValid() bool {
+	switch f {
+	case SortByCreatedAt, SortByUpdatedAt, SortByACKedAt:
+		return true
+	default:
+		return false
+	}
+}
+
+// Timestamp returns the value of the sort field for an alarm. Unset or
+// unparsable timestamps are returned as the zero time.
+func (f SortField) Timestamp(alarm Alarm) time.Time {
+	var value string
+	switch f {
+	case SortByUpdatedAt:
+		value = alarm.UpdatedAt
+	case SortByACKedAt:
+		value = alarm.ACKedAt
+	default:
+		value = alarm.CreatedAt
+	}
+	timestamp, _ := time.Parse(time.RFC3339, value)
+	return timestamp
+}
+
+// ShelvedFilter selects how shelved alarms are treated by an alarm listing.
+type ShelvedFilter string
+
+const (
+	ShelvedExclude ShelvedFilter = ""        // Leave shelved alarms out (default)
+	ShelvedInclude ShelvedFilter = "include" // List shelved alarms with the others
+	ShelvedOnly    ShelvedFilter = "only"    // List shelved alarms only
+)
+
+// IsValid checks if the provided shelved filter is valid.
+func (f ShelvedFilter) IsValid() bool {
+	return f == ShelvedExclude || f == ShelvedInclude || f == ShelvedOnly
+}
+
+// AlarmQuery filters, orders and pages an alarm listing. Zero-valued fields do not filter;
+// multiple values of a field match any of them, while distinct fields must all match.
+type AlarmQuery struct {
+	States        []AlarmState      // Lifecycle states to include
+	Severities    []Severity        // Severities to include
+	NameContains  string            // Case-insensitive substring of the alarm name
+	CreatedAfter  time.Time         // Include alarms created at or after this time
+	CreatedBefore time.Time         // Include alarms created before this time
+	UpdatedAfter  time.Time         // Include alarms updated at or after this time
+	UpdatedBefore time.Time         // Include alarms updated before this time
+	Labels        map[string]string // Labels the alarm must carry with exactly these values
+	Selector      []Matcher         // Label selector the alarm must satisfy, see ParseSelector
+	Shelved       ShelvedFilter     // Whether shelved alarms are excluded (default), included or the only ones listed
+	SortBy        SortField         // Timestamp to order by, defaults to created_at
+	Descending    bool              // Order newest first
+	Limit         int               // Maximum number of alarms per page; zero uses the default page size
+	Cursor        string            // Opaque cursor returned as AlarmPage.NextCursor
+}
+
+// AlarmPage is a page of an alarm listing.
+type AlarmPage struct {
+	Alarms     []Alarm `json:"alarms"`                // Alarms on this page, in query order
+	NextCursor string  `json:"next_cursor,omitempty"` // Cursor of the next page, empty on the last page
+}
+
+// Matches reports whether an alarm satisfies the filters of the query.
+func (q AlarmQuery) Matches(alarm Alarm) bool {
+	if shelved := alarm.Suppression == Shelved; (q.Shelved == ShelvedExclude && shelved) || (q.Shelved == ShelvedOnly && !shelved) {
+		return false
+	}
+	if len(q.States) > 0 && !slices.Contains(q.States, alarm.State) {
+		return false
+	}
+	if len(q.Severities) > 0 && !slices.Contains(q.Severities, alarm.Severity) {
+		return false
+	}
+	if q.NameContains != "" && !strings.Contains(strings.ToLower(alarm.Name), strings.ToLower(q.NameContains)) {
+		return false
+	}
+	if !inRange(SortByCreatedAt.Timestamp(alarm), q.CreatedAfter, q.CreatedBefore) {
+		return false
+	}
+	if !inRange(SortByUpdatedAt.Timestamp(alarm), q.UpdatedAfter, q.UpdatedBefore) {
+		return false
+	}
+	for key, value := range q.Labels {
+		if actual, exists := alarm.Labels[key]; !exists || actual != value {
+			return false
+		}
+	}
+	return MatchAll(q.Selector, alarm)
+}
+
+// LabelMatchers returns the label filters of the query, Labels and Selector, as matchers.
+func (q AlarmQuery) LabelMatchers() []Matcher {
+	matchers := append([]Matcher{}, q.Selector...)
+	for key, value := range q.Labels {
+		matchers = append(matchers, Matcher{Name: key, Value: value})
+	}
+	return matchers
+}
+
+// inRange reports whether timestamp lies in [after, before), treating zero bounds as open.
+// An unset timestamp only matches when both bounds are open.
+func inRange(timestamp, after, before time.Time) bool {
+	if after.IsZero() && before.IsZero() {
+		return true
+	}
+	if timestamp.IsZero() {
+		return false
+	}
+	return !timestamp.Before(after) && (before.IsZero() || timestamp.Before(before))
+}
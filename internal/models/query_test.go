@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestAlarmQueryMatches tests each alarm query filter.
+func TestAlarmQueryMatches(t *testing.T) {
+	alarm := Alarm{
+		Name:      "Disk Failure",
+		State:     Triggered,
+		Severity:  Critical,
+		CreatedAt: "2024-01-01T10:00:00Z",
+		Labels:    map[string]string{"team": "storage"},
+	}
+	created := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	assert.True(t, AlarmQuery{}.Matches(alarm), "Expected an empty query to match")
+	assert.True(t, AlarmQuery{States: []AlarmState{ACKed, Triggered}, Severities: []Severity{Critical}}.Matches(alarm))
+	assert.False(t, AlarmQuery{States: []AlarmState{Cleared}}.Matches(alarm), "Expected state filter to exclude")
+	assert.True(t, AlarmQuery{NameContains: "disk"}.Matches(alarm), "Expected case-insensitive name match")
+	assert.True(t, AlarmQuery{CreatedAfter: created, CreatedBefore: created.Add(time.Second)}.Matches(alarm))
+	assert.False(t, AlarmQuery{CreatedBefore: created}.Matches(alarm), "Expected created_before to be exclusive")
+	assert.False(t, AlarmQuery{UpdatedAfter: created}.Matches(alarm), "Expected never-updated alarm to be excluded")
+	assert.True(t, AlarmQuery{Labels: map[string]string{"team": "storage"}}.Matches(alarm))
+	assert.False(t, AlarmQuery{Labels: map[string]string{"team": "network"}}.Matches(alarm), "Expected label mismatch to exclude")
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// Route is a node of the notification routing tree. A notification that matches a route is
+// passed down to its child routes in order, stopping at the first matching child unless that
+// child has Continue set; it is delivered to the receivers of every matching route none of
+// whose children matched. The root route is the default route and matches every alarm.
+type Route struct {
+	Name      string    `json:"name,omitempty"`
+	Matchers  []Matcher `json:"matchers,omitempty"`  // Alarms the route applies to; all alarms when empty
+	Receivers []string  `json:"receivers,omitempty"` // Notifier names or schedule targets; inherited from the parent route when empty
+	Continue  bool      `json:"continue,omitempty"`  // Keep evaluating sibling routes after this one matched
+	Routes    []Route   `json:"routes,omitempty"`    // Child routes, evaluated in order
+}
+
+// RouteMatch is a route a notification was delivered through.
+type RouteMatch struct {
+	Route     string   `json:"route"`     // Name of the route, or its path in the tree such as "root.routes[1]"
+	Receivers []string `json:"receivers"` // Receivers of the route, including inherited ones
+}
+
+// RoutingResult reports the routes an alarm matched and the receivers its notifications reach.
+type RoutingResult struct {
+	Routes           []RouteMatch `json:"routes"`
+	EscalationPolicy string       `json:"escalation_policy,omitempty"` // Policy whose current tier is paged instead of the routes
+	Receivers        []string     `json:"receivers"`                   // Receivers of every matched route, without duplicates
+}
+
+// Validate checks that the route is a valid root route: it has no matchers, so that it
+// matches every alarm, and default receivers, and every route below it has valid matchers.
+func (r Route) Validate() error {
+	if len(r.Matchers) != 0 {
+		return errors.New("the root route matches every alarm and cannot have matchers")
+	}
+	if len(r.Receivers) == 0 {
+		return errors.New("the root route needs at least one default receiver")
+	}
+	return r.validate("root")
+}
+
+// validate checks the matchers of the route and of its child routes.
+func (r Route) validate(path string) error {
+	for _, matcher := range r.Matchers {
+		if err := matcher.Validate(); err != nil {
+			return fmt.Errorf("route %s: %w", r.label(path), err)
+		}
+	}
+	for i, child := range r.Routes {
+		if err := child.validate(fmt.Sprintf("%s.routes[%d]", path, i)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Match evaluates the routing tree rooted at the route for an alarm.
+func (r Route) Match(alarm Alarm) RoutingResult {
+	result := RoutingResult{Routes: []RouteMatch{}, Receivers: []string{}}
+	r.match(alarm, "root", nil, &result)
+	for _, match := range result.Routes {
+		for _, receiver := range match.Receivers {
+			if !slices.Contains(result.Receivers, receiver) {
+				result.Receivers = append(result.Receivers, receiver)
+			}
+		}
+	}
+	return result
+}
+
+// match reports whether the alarm matches the route, recording the routes it is delivered through.
+func (r Route) match(alarm Alarm, path string, inherited []string, result *RoutingResult) bool {
+	if !MatchAll(r.Matchers, alarm) {
+		return false
+	}
+	receivers := r.Receivers
+	if len(receivers) == 0 {
+		receivers = inherited
+	}
+
+	matchedChild := false
+	for i, child := range r.Routes {
+		if !child.match(alarm, fmt.Sprintf("%s.routes[%d]", path, i), receivers, result) {
+			continue
+		}
+		matchedChild = true
+		if !child.Continue {
+			break
+		}
+	}
+	if !matchedChild {
+		result.Routes = append(result.Routes, RouteMatch{Route: r.label(path), Receivers: receivers})
+	}
+	return true
+}
+
+// label returns the name of the route, or its path in the tree if it has none.
+func (r Route) label(path string) string {
+	if r.Name != "" {
+		return r.Name
+	}
+	return path
+}
+
+// Walk calls fn for the route and every route below it.
+func (r Route) Walk(fn func(Route)) {
+	fn(r)
+	for _, child := range r.Routes {
+		child.Walk(fn)
+	}
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestRouteMatch tests first-match and continue semantics, receiver inheritance and the default route.
+func TestRouteMatch(t *testing.T) {
+	root := Route{
+		Receivers: []string{"console"},
+		Routes: []Route{
+			{Name: "audit", Receivers: []string{"webhook"}, Continue: true},
+			{Name: "critical", Matchers: []Matcher{{Name: MatchSeverity, Value: "Critical"}}, Receivers: []string{"email"},
+				Routes: []Route{{Matchers: []Matcher{{Name: "team", Value: "db"}}, Receivers: []string{"schedule:database"}}}},
+			{Matchers: []Matcher{{Name: "team", Value: "db"}}},
+		},
+	}
+	assert.NoError(t, root.Validate())
+
+	result := root.Match(Alarm{Name: "Replication Lag", Severity: Critical, Labels: map[string]string{"team": "db"}})
+	assert.Equal(t, []RouteMatch{
+		{Route: "audit", Receivers: []string{"webhook"}},
+		{Route: "root.routes[1].routes[0]", Receivers: []string{"schedule:database"}},
+	}, result.Routes)
+	assert.Equal(t, []string{"webhook", "schedule:database"}, result.Receivers)
+
+	result = root.Match(Alarm{Name: "Slow Queries", Severity: Minor, Labels: map[string]string{"team": "db"}})
+	assert.Equal(t, []string{"webhook", "console"}, result.Receivers, "Expected a route without receivers to inherit the default ones")
+
+	root.Routes[0].Continue = false
+	result = root.Match(Alarm{Name: "Disk Full", Severity: Critical})
+	assert.Equal(t, []string{"webhook"}, result.Receivers, "Expected evaluation to stop at the first matching route")
+
+	root.Routes = root.Routes[1:]
+	result = root.Match(Alarm{Name: "Disk Full", Severity: Minor})
+	assert.Equal(t, []RouteMatch{{Route: "root", Receivers: []string{"console"}}}, result.Routes, "Expected the default route")
+
+	assert.Error(t, Route{}.Validate())
+	assert.Error(t, Route{Receivers: []string{"console"}, Matchers: []Matcher{{Name: "team", Value: "db"}}}.Validate())
+	assert.Error(t, Route{Receivers: []string{"console"}, Routes: []Route{{Matchers: []Matcher{{Name: "team", Value: "(", IsRegex: true}}}}}.Validate())
+}
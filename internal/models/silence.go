@@ -0,0 +1,157 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+	_ "time/tzdata" // Resolve recurrence time zones on hosts without a zoneinfo database
+)
+
+// SilenceStatus describes whether a silence is yet to start, in effect or over.
+type SilenceStatus string
+
+const (
+	SilencePending SilenceStatus = "pending" // Starts in the future
+	SilenceActive  SilenceStatus = "active"  // In effect, possibly between recurring windows
+	SilenceExpired SilenceStatus = "expired" // Ended or expired manually
+)
+
+// Silence suppresses the notifications of matching alarms for a period of time, such as a
+// maintenance window. Matching alarms are still stored and updated.
+type Silence struct {
+	ID         string        `json:"id"`
+	Matchers   []Matcher     `json:"matchers"`             // Alarms must satisfy every matcher
+	StartsAt   string        `json:"starts_at"`            // RFC 3339 start, defaults to creation time
+	EndsAt     string        `json:"ends_at,omitempty"`    // RFC 3339 end; optional for recurring silences
+	Recurrence *Recurrence   `json:"recurrence,omitempty"` // Restricts the silence to recurring windows
+	CreatedBy  string        `json:"created_by"`           // Who created the silence
+	Comment    string        `json:"comment"`              // Why the silence was created
+	CreatedAt  string        `json:"created_at"`
+	Status     SilenceStatus `json:"status"` // Derived from the current time when read
+}
+
+// Recurrence is a daily or weekly window in a given time zone, such as every Sunday
+// from 02:00 to 04:00 in Europe/Berlin.
+type Recurrence struct {
+	Weekdays  []string `json:"weekdays,omitempty"`  // Days the window starts on, e.g. "Sunday"; every day when empty
+	StartTime string   `json:"start_time"`          // Local start of the window as "15:04"
+	EndTime   string   `json:"end_time"`            // Local end as "15:04"; before start_time for windows crossing midnight
+	TimeZone  string   `json:"time_zone,omitempty"` // IANA time zone name, defaults to UTC
+}
+
+// StatusAt returns the status of the silence at the given time.
+func (s Silence) StatusAt(t time.Time) SilenceStatus {
+	if startsAt, err := time.Parse(time.RFC3339, s.StartsAt); err == nil && t.Before(startsAt) {
+		return SilencePending
+	}
+	if endsAt, err := time.Parse(time.RFC3339, s.EndsAt); err == nil && !t.Before(endsAt) {
+		return SilenceExpired
+	}
+	return SilenceActive
+}
+
+// Silences reports whether the silence suppresses the notifications of an alarm at the given time.
+func (s Silence) Silences(alarm Alarm, t time.Time) bool {
+	if s.StatusAt(t) != SilenceActive {
+		return false
+	}
+	if s.Recurrence != nil && !s.Recurrence.Contains(t) {
+		return false
+	}
+	return MatchAll(s.Matchers, alarm)
+}
+
+// locationCache holds loaded time zones, keyed by name.
+var locationCache sync.Map
+
+// Validate checks the weekdays, times and time zone of the recurrence.
+func (r Recurrence) Validate() error {
+	for _, day := range r.Weekdays {
+		if _, ok := parseWeekday(day); !ok {
+			return fmt.Errorf("invalid weekday %q", day)
+		}
+	}
+	start, startErr := time.Parse("15:04", r.StartTime)
+	end, endErr := time.Parse("15:04", r.EndTime)
+	if startErr != nil || endErr != nil {
+		return errors.New("recurrence start_time and end_time must be formatted as 15:04")
+	}
+	if start.Equal(end) {
+		return errors.New("recurrence start_time and end_time must differ")
+	}
+	if _, err := r.location(); err != nil {
+		return fmt.Errorf("unknown time zone %q", r.TimeZone)
+	}
+	return nil
+}
+
+// Contains reports whether t falls within a window of the recurrence. A window crossing
+// midnight belongs to the weekday it starts on.
+func (r Recurrence) Contains(t time.Time) bool {
+	loc, err := r.location()
+	if err != nil {
+		return false
+	}
+	start, startErr := time.Parse("15:04", r.StartTime)
+	end, endErr := time.Parse("15:04", r.EndTime)
+	if startErr != nil || endErr != nil {
+		return false
+	}
+
+	local := t.In(loc)
+	for _, offset := range []int{0, -1} {
+		day := time.Date(local.Year(), local.Month(), local.Day()+offset, 0, 0, 0, 0, loc)
+		if !r.onWeekday(day.Weekday()) {
+			continue
+		}
+		windowStart := time.Date(day.Year(), day.Month(), day.Day(), start.Hour(), start.Minute(), 0, 0, loc)
+		windowEnd := time.Date(day.Year(), day.Month(), day.Day(), end.Hour(), end.Minute(), 0, 0, loc)
+		if !windowEnd.After(windowStart) {
+			windowEnd = windowEnd.AddDate(0, 0, 1)
+		}
+		if !t.Before(windowStart) && t.Before(windowEnd) {
+			return true
+		}
+	}
+	return false
+}
+
+// onWeekday reports whether a window starts on the given weekday.
+func (r Recurrence) onWeekday(weekday time.Weekday) bool {
+	if len(r.Weekdays) == 0 {
+		return true
+	}
+	return slices.ContainsFunc(r.Weekdays, func(day string) bool {
+		parsed, ok := parseWeekday(day)
+		return ok && parsed == weekday
+	})
+}
+
+// location returns the time zone of the recurrence.
+func (r Recurrence) location() (*time.Location, error) {
+	if r.TimeZone == "" {
+		return time.UTC, nil
+	}
+	if cached, ok := locationCache.Load(r.TimeZone); ok {
+		return cached.(*time.Location), nil
+	}
+	loc, err := time.LoadLocation(r.TimeZone)
+	if err != nil {
+		return nil, err
+	}
+	locationCache.Store(r.TimeZone, loc)
+	return loc, nil
+}
+
+// parseWeekday parses a case-insensitive English weekday name.
+func parseWeekday(day string) (time.Weekday, bool) {
+	for weekday := time.Sunday; weekday <= time.Saturday; weekday++ {
+		if strings.EqualFold(weekday.String(), day) {
+			return weekday, true
+		}
+	}
+	return 0, false
+}
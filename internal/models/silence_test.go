@@ -0,0 +1,88 @@
+package models
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMatcher tests equality and regex matchers on alarm fields and labels.
+func TestMatcher(t *testing.T) {
+	alarm := Alarm{Name: "Disk Space Alert", Severity: Major, Source: "db-1", Labels: map[string]string{"team": "storage"}}
+
+	assert.True(t, Matcher{Name: MatchAlarmName, Value: "Disk Space Alert"}.Matches(alarm))
+	assert.True(t, Matcher{Name: MatchSeverity, Value: "Major|Critical", IsRegex: true}.Matches(alarm))
+	assert.True(t, Matcher{Name: MatchSource, Value: "db-.*", IsRegex: true}.Matches(alarm))
+	assert.True(t, Matcher{Name: "team", Value: "storage"}.Matches(alarm))
+	assert.False(t, Matcher{Name: "team", Value: "stor", IsRegex: true}.Matches(alarm), "Expected regex anchored at both ends")
+	assert.False(t, Matcher{Name: "region", Value: "eu"}.Matches(alarm), "Expected missing label to have the empty value")
+	assert.True(t, Matcher{Name: "region", Value: ""}.Matches(alarm))
+
+	assert.Error(t, Matcher{Value: "x"}.Validate())
+	assert.Error(t, Matcher{Name: "team", Value: "(", IsRegex: true}.Validate())
+	assert.False(t, Matcher{Name: "team", Value: "(", IsRegex: true, IsNegative: true}.Matches(alarm), "Expected invalid negative regex to match nothing")
+	assert.False(t, MatchAll([]Matcher{{Name: "team", Value: "storage"}, {Name: MatchSeverity, Value: "Critical"}}, alarm))
+}
+
+// TestRegexCache tests that the regex cache evicts the least recently used expressions once full.
+func TestRegexCache(t *testing.T) {
+	cache := newRegexLRU(2)
+	for _, pattern := range []string{"web-0", "web-1"} {
+		re, err := Matcher{Name: "host", Value: pattern, IsRegex: true}.regex()
+		assert.NoError(t, err)
+		cache.put(pattern, re)
+	}
+	cache.get("web-0")
+	cache.put("web-2", nil)
+
+	assert.Equal(t, 2, cache.len())
+	_, found := cache.get("web-1")
+	assert.False(t, found, "Expected least recently used expression to be evicted")
+	_, found = cache.get("web-0")
+	assert.True(t, found)
+
+	for i := 0; i < 2*maxCachedRegexes; i++ {
+		Matcher{Name: "host", Value: fmt.Sprintf("host-%d.*", i), IsRegex: true}.MatchesValue("host-1")
+	}
+	assert.LessOrEqual(t, regexCache.len(), maxCachedRegexes)
+}
+
+// TestRecurrenceContains tests weekly windows in a time zone, including windows crossing midnight.
+func TestRecurrenceContains(t *testing.T) {
+	berlin, _ := time.LoadLocation("Europe/Berlin")
+	sunday := Recurrence{Weekdays: []string{"sunday"}, StartTime: "02:00", EndTime: "04:00", TimeZone: "Europe/Berlin"}
+	assert.NoError(t, sunday.Validate())
+
+	assert.True(t, sunday.Contains(time.Date(2024, 1, 7, 2, 0, 0, 0, berlin)))
+	assert.True(t, sunday.Contains(time.Date(2024, 1, 7, 2, 30, 0, 0, time.UTC)), "Expected 03:30 in Berlin")
+	assert.False(t, sunday.Contains(time.Date(2024, 1, 7, 4, 0, 0, 0, berlin)), "Expected the end to be exclusive")
+	assert.False(t, sunday.Contains(time.Date(2024, 1, 8, 3, 0, 0, 0, berlin)), "Expected Monday to be outside the window")
+
+	overnight := Recurrence{Weekdays: []string{"Saturday"}, StartTime: "22:00", EndTime: "02:00"}
+	assert.True(t, overnight.Contains(time.Date(2024, 1, 6, 23, 0, 0, 0, time.UTC)))
+	assert.True(t, overnight.Contains(time.Date(2024, 1, 7, 1, 0, 0, 0, time.UTC)), "Expected Sunday morning to belong to Saturday's window")
+	assert.False(t, overnight.Contains(time.Date(2024, 1, 7, 23, 0, 0, 0, time.UTC)))
+
+	assert.Error(t, Recurrence{Weekdays: []string{"Funday"}, StartTime: "02:00", EndTime: "04:00"}.Validate())
+	assert.Error(t, Recurrence{StartTime: "2am", EndTime: "04:00"}.Validate())
+	assert.Error(t, Recurrence{StartTime: "02:00", EndTime: "04:00", TimeZone: "Mars/Olympus"}.Validate())
+}
+
+// TestSilenceStatusAt tests the status of a silence over time.
+func TestSilenceStatusAt(t *testing.T) {
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)
+	silence := Silence{
+		Matchers: []Matcher{{Name: "team", Value: "storage"}},
+		StartsAt: start.Format(time.RFC3339),
+		EndsAt:   start.Add(time.Hour).Format(time.RFC3339),
+	}
+	alarm := Alarm{Name: "Disk Space Alert", Labels: map[string]string{"team": "storage"}}
+
+	assert.Equal(t, SilencePending, silence.StatusAt(start.Add(-time.Minute)))
+	assert.Equal(t, SilenceActive, silence.StatusAt(start))
+	assert.Equal(t, SilenceExpired, silence.StatusAt(start.Add(time.Hour)))
+	assert.True(t, silence.Silences(alarm, start.Add(time.Minute)))
+	assert.False(t, silence.Silences(alarm, start.Add(2*time.Hour)))
+}
@@ -0,0 +1,313 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	htmltemplate "html/template"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/smtp"
+	"net/textproto"
+	"strconv"
+	"strings"
+	texttemplate "text/template"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+const (
+	defaultSMTPPort           = 587
+	defaultRecipientsPerBatch = 50
+	defaultEmailSubject       = `[{{.Alarm.Severity}}] {{.Alarm.Name}} is {{.Alarm.State}}`
+	defaultEmailTextBody      = "Alarm:    {{.Alarm.Name}} ({{.Alarm.ID}})\nSeverity: {{.Alarm.Severity}}\nState:    {{.Alarm.State}} ({{.Alarm.ISAState}}){{if .PreviousState}}, was {{.PreviousState}}{{end}}\nReason:   {{.Reason}}\n{{if .Alarm.ACKedBy}}ACKed by: {{.Alarm.ACKedBy}}{{if .Alarm.AckComment}} ({{.Alarm.AckComment}}){{end}}\n{{end}}Notification #{{.Count}}\n"
+	defaultEmailHTMLBody      = `<p><strong>{{.Alarm.Name}}</strong> ({{.Alarm.ID}})</p><table><tr><td>Severity</td><td>{{.Alarm.Severity}}</td></tr><tr><td>State</td><td>{{.Alarm.State}} ({{.Alarm.ISAState}}){{if .PreviousState}}, was {{.PreviousState}}{{end}}</td></tr><tr><td>Reason</td><td>{{.Reason}}</td></tr>{{if .Alarm.ACKedBy}}<tr><td>ACKed by</td><td>{{.Alarm.ACKedBy}}{{if .Alarm.AckComment}} ({{.Alarm.AckComment}}){{end}}</td></tr>{{end}}<tr><td>Notification</td><td>#{{.Count}}</td></tr></table>`
+	emailDialTimeout          = 10 * time.Second
+	emailLocalName            = "localhost"
+)
+
+// EmailTemplates holds the subject and body templates of an email notification.
+// Subject and Text use text/template, HTML uses html/template; all are executed
+// with the models.Notification being delivered. Empty fields fall back to defaults.
+type EmailTemplates struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// EmailConfig configures an EmailNotifier.
+type EmailConfig struct {
+	Name               string                               // Notifier name, defaults to "email"
+	Host               string                               // SMTP relay host
+	Port               int                                  // SMTP relay port, defaults to 587
+	Username           string                               // Username for PLAIN auth; auth is skipped when empty
+	Password           string                               // Password for PLAIN auth
+	StartTLS           bool                                 // Require upgrading the connection with STARTTLS
+	TLSConfig          *tls.Config                          // TLS settings for STARTTLS, defaults to verifying Host
+	From               string                               // Envelope and header sender address
+	To                 []string                             // Recipient addresses
+	RecipientsPerBatch int                                  // Recipients per message, defaults to 50
+	Templates          EmailTemplates                       // Default templates
+	StateTemplates     map[models.AlarmState]EmailTemplates // Per-state overrides of the default templates
+}
+
+// emailTemplateSet is a parsed EmailTemplates.
+type emailTemplateSet struct {
+	subject *texttemplate.Template
+	text    *texttemplate.Template
+	html    *htmltemplate.Template
+}
+
+// EmailNotifier delivers notifications as multipart text/HTML emails through an SMTP relay.
+type EmailNotifier struct {
+	config   EmailConfig
+	defaults emailTemplateSet
+	perState map[models.AlarmState]emailTemplateSet
+}
+
+// NewEmailNotifier initializes an EmailNotifier, parsing its templates and applying
+// defaults to unset config fields.
+func NewEmailNotifier(config EmailConfig) (*EmailNotifier, error) {
+	if config.Host == "" {
+		return nil, errors.New("email notifier requires an SMTP host")
+	}
+	if config.From == "" || len(config.To) == 0 {
+		return nil, errors.New("email notifier requires a sender and at least one recipient")
+	}
+	if config.Name == "" {
+		config.Name = "email"
+	}
+	if config.Port == 0 {
+		config.Port = defaultSMTPPort
+	}
+	if config.RecipientsPerBatch <= 0 {
+		config.RecipientsPerBatch = defaultRecipientsPerBatch
+	}
+
+	base := EmailTemplates{
+		Subject: firstNonEmpty(config.Templates.Subject, defaultEmailSubject),
+		Text:    firstNonEmpty(config.Templates.Text, defaultEmailTextBody),
+		HTML:    firstNonEmpty(config.Templates.HTML, defaultEmailHTMLBody),
+	}
+	defaults, err := parseEmailTemplates(base, EmailTemplates{})
+	if err != nil {
+		return nil, err
+	}
+	notifier := &EmailNotifier{
+		config:   config,
+		defaults: defaults,
+		perState: make(map[models.AlarmState]emailTemplateSet),
+	}
+
+	for state, templates := range config.StateTemplates {
+		set, err := parseEmailTemplates(base, templates)
+		if err != nil {
+			return nil, fmt.Errorf("state %s: %w", state, err)
+		}
+		notifier.perState[state] = set
+	}
+	return notifier, nil
+}
+
+// Name returns the notifier name.
+func (e *EmailNotifier) Name() string {
+	return e.config.Name
+}
+
+// Notify renders the notification and sends it to all recipients, batching
+// up to RecipientsPerBatch recipients per SMTP transaction. A notification paging
+// on-call users is sent to their addresses instead of the configured ones.
+func (e *EmailNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	to := e.config.To
+	if addresses := recipientAddresses(notification); len(addresses) > 0 {
+		to = addresses
+	}
+
+	var errs []error
+	for start := 0; start < len(to); start += e.config.RecipientsPerBatch {
+		end := min(start+e.config.RecipientsPerBatch, len(to))
+		batch := to[start:end]
+
+		message, err := e.render(notification, batch)
+		if err != nil {
+			return err
+		}
+		if err := e.send(ctx, batch, message); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// render builds the MIME message for a notification addressed to recipients.
+func (e *EmailNotifier) render(notification models.Notification, recipients []string) ([]byte, error) {
+	templates, exists := e.perState[notification.Alarm.State]
+	if !exists {
+		templates = e.defaults
+	}
+
+	var subject, text, html bytes.Buffer
+	if err := templates.subject.Execute(&subject, notification); err != nil {
+		return nil, fmt.Errorf("failed to render email subject: %w", err)
+	}
+	if err := templates.text.Execute(&text, notification); err != nil {
+		return nil, fmt.Errorf("failed to render email text body: %w", err)
+	}
+	if err := templates.html.Execute(&html, notification); err != nil {
+		return nil, fmt.Errorf("failed to render email HTML body: %w", err)
+	}
+
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+	for _, part := range []struct {
+		contentType string
+		content     []byte
+	}{
+		{"text/plain; charset=utf-8", text.Bytes()},
+		{"text/html; charset=utf-8", html.Bytes()},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		encoder.Write(part.content)
+		encoder.Close()
+	}
+	parts.Close()
+
+	var message bytes.Buffer
+	headers := []struct{ key, value string }{
+		{"From", e.config.From},
+		{"To", strings.Join(recipients, ", ")},
+		{"Subject", mime.QEncoding.Encode("utf-8", stripLineBreaks(subject.String()))},
+		{"Date", time.Now().Format(time.RFC1123Z)},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	}
+	for _, header := range headers {
+		fmt.Fprintf(&message, "%s: %s\r\n", header.key, header.value)
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+// send delivers a rendered message to recipients in a single SMTP transaction.
+func (e *EmailNotifier) send(ctx context.Context, recipients []string, message []byte) error {
+	addr := net.JoinHostPort(e.config.Host, strconv.Itoa(e.config.Port))
+	dialer := net.Dialer{Timeout: emailDialTimeout}
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("failed to connect to SMTP relay: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+
+	client, err := smtp.NewClient(conn, e.config.Host)
+	if err != nil {
+		conn.Close()
+		return fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+	defer client.Close()
+
+	if err := client.Hello(emailLocalName); err != nil {
+		return fmt.Errorf("SMTP HELO failed: %w", err)
+	}
+	if e.config.StartTLS {
+		if ok, _ := client.Extension("STARTTLS"); !ok {
+			return errors.New("SMTP relay does not support STARTTLS")
+		}
+		tlsConfig := e.config.TLSConfig
+		if tlsConfig == nil {
+			tlsConfig = &tls.Config{ServerName: e.config.Host}
+		}
+		if err := client.StartTLS(tlsConfig); err != nil {
+			return fmt.Errorf("SMTP STARTTLS failed: %w", err)
+		}
+	}
+	if e.config.Username != "" {
+		auth := smtp.PlainAuth("", e.config.Username, e.config.Password, e.config.Host)
+		if err := client.Auth(auth); err != nil {
+			return fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	if err := client.Mail(e.config.From); err != nil {
+		return fmt.Errorf("SMTP MAIL FROM failed: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return fmt.Errorf("SMTP RCPT TO %s failed: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return fmt.Errorf("SMTP DATA failed: %w", err)
+	}
+	if _, err := writer.Write(message); err != nil {
+		writer.Close()
+		return fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return fmt.Errorf("SMTP relay rejected email: %w", err)
+	}
+	return client.Quit()
+}
+
+// parseEmailTemplates parses overrides on top of base, using base for any empty field.
+func parseEmailTemplates(base, overrides EmailTemplates) (emailTemplateSet, error) {
+	subject, err := texttemplate.New("subject").Parse(firstNonEmpty(overrides.Subject, base.Subject))
+	if err != nil {
+		return emailTemplateSet{}, fmt.Errorf("invalid subject template: %w", err)
+	}
+	text, err := texttemplate.New("text").Parse(firstNonEmpty(overrides.Text, base.Text))
+	if err != nil {
+		return emailTemplateSet{}, fmt.Errorf("invalid text template: %w", err)
+	}
+	html, err := htmltemplate.New("html").Parse(firstNonEmpty(overrides.HTML, base.HTML))
+	if err != nil {
+		return emailTemplateSet{}, fmt.Errorf("invalid HTML template: %w", err)
+	}
+	return emailTemplateSet{subject: subject, text: text, html: html}, nil
+}
+
+// recipientAddresses returns the email addresses of the on-call users a notification pages.
+func recipientAddresses(notification models.Notification) []string {
+	var addresses []string
+	for _, user := range notification.Recipients {
+		if user.Email != "" {
+			addresses = append(addresses, user.Email)
+		}
+	}
+	return addresses
+}
+
+// firstNonEmpty returns the first non-empty string of values.
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// stripLineBreaks removes CR and LF characters so rendered values cannot inject headers.
+func stripLineBreaks(value string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '\r' || r == '\n' {
+			return ' '
+		}
+		return r
+	}, value)
+}
@@ -0,0 +1,250 @@
+package notify_test
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
+)
+
+// smtpMessage is a message accepted by the fake SMTP server.
+type smtpMessage struct {
+	from       string
+	recipients []string
+	data       string
+	authed     bool
+}
+
+// fakeSMTPServer is a minimal in-process SMTP server recording accepted messages.
+type fakeSMTPServer struct {
+	listener net.Listener
+
+	lock     sync.Mutex
+	messages []smtpMessage
+}
+
+// newFakeSMTPServer starts a fake SMTP server on a random local port.
+func newFakeSMTPServer(t *testing.T) *fakeSMTPServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to listen: %v", err)
+	}
+	server := &fakeSMTPServer{listener: listener}
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go server.serve(conn)
+		}
+	}()
+	return server
+}
+
+// port returns the port the server listens on.
+func (s *fakeSMTPServer) port() int {
+	return s.listener.Addr().(*net.TCPAddr).Port
+}
+
+// received returns the messages accepted so far.
+func (s *fakeSMTPServer) received() []smtpMessage {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	return append([]smtpMessage{}, s.messages...)
+}
+
+// serve handles a single SMTP session.
+func (s *fakeSMTPServer) serve(conn net.Conn) {
+	defer conn.Close()
+
+	reader := bufio.NewReader(conn)
+	reply := func(line string) { io.WriteString(conn, line+"\r\n") }
+	var current smtpMessage
+
+	reply("220 localhost ESMTP fake")
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		command := strings.ToUpper(strings.TrimSpace(line))
+
+		switch {
+		case strings.HasPrefix(command, "EHLO"):
+			reply("250-localhost")
+			reply("250 AUTH PLAIN")
+		case strings.HasPrefix(command, "AUTH PLAIN"):
+			current.authed = true
+			reply("235 2.7.0 Authentication successful")
+		case strings.HasPrefix(command, "MAIL FROM:"):
+			current.from = strings.Trim(strings.TrimSpace(line)[len("MAIL FROM:"):], "<>")
+			reply("250 OK")
+		case strings.HasPrefix(command, "RCPT TO:"):
+			current.recipients = append(current.recipients, strings.Trim(strings.TrimSpace(line)[len("RCPT TO:"):], "<>"))
+			reply("250 OK")
+		case command == "DATA":
+			reply("354 End data with <CR><LF>.<CR><LF>")
+			var data strings.Builder
+			for {
+				dataLine, err := reader.ReadString('\n')
+				if err != nil {
+					return
+				}
+				if dataLine == ".\r\n" {
+					break
+				}
+				data.WriteString(strings.TrimPrefix(dataLine, "."))
+			}
+			current.data = data.String()
+			s.lock.Lock()
+			s.messages = append(s.messages, current)
+			s.lock.Unlock()
+			current = smtpMessage{authed: current.authed}
+			reply("250 OK queued")
+		case command == "QUIT":
+			reply("221 Bye")
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+}
+
+// parseEmail extracts the decoded subject and text part of a message.
+func parseEmail(t *testing.T, data string) (string, string) {
+	t.Helper()
+
+	msg, err := mail.ReadMessage(strings.NewReader(data))
+	if err != nil {
+		t.Fatalf("failed to parse message: %v", err)
+	}
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	if err != nil {
+		t.Fatalf("failed to decode subject: %v", err)
+	}
+
+	_, params, _ := mime.ParseMediaType(msg.Header.Get("Content-Type"))
+	part, err := multipart.NewReader(msg.Body, params["boundary"]).NextPart()
+	if err != nil {
+		t.Fatalf("failed to read text part: %v", err)
+	}
+	text, _ := io.ReadAll(quotedprintable.NewReader(part))
+	return subject, string(text)
+}
+
+// TestEmailNotifier_Notify verifies templated subject and body and recipient batching.
+func TestEmailNotifier_Notify(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	notifier, err := notify.NewEmailNotifier(notify.EmailConfig{
+		Host:               "127.0.0.1",
+		Port:               server.port(),
+		Username:           "alarms",
+		Password:           "secret",
+		From:               "alarms@example.com",
+		To:                 []string{"a@example.com", "b@example.com", "c@example.com"},
+		RecipientsPerBatch: 2,
+		StateTemplates: map[models.AlarmState]notify.EmailTemplates{
+			models.ACKed: {Subject: `{{.Alarm.Name}} acknowledged`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	notification := models.Notification{
+		Alarm:  models.Alarm{ID: "a1", Name: "Datacenter Outage", State: models.Triggered, Severity: models.Critical},
+		Reason: models.ReasonCreated,
+		Count:  1,
+	}
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	messages := server.received()
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 batched messages, got %d", len(messages))
+	}
+	if len(messages[0].recipients) != 2 || len(messages[1].recipients) != 1 {
+		t.Errorf("expected batches of 2 and 1 recipients, got %v and %v", messages[0].recipients, messages[1].recipients)
+	}
+	if !messages[0].authed || messages[0].from != "alarms@example.com" {
+		t.Errorf("expected authenticated message from alarms@example.com, got %+v", messages[0])
+	}
+
+	subject, text := parseEmail(t, messages[0].data)
+	if subject != "[Critical] Datacenter Outage is Triggered" {
+		t.Errorf("unexpected default subject %q", subject)
+	}
+	if !strings.Contains(text, "Reason:   created") {
+		t.Errorf("expected text body to contain the reason, got %q", text)
+	}
+
+	notification.Alarm.State = models.ACKed
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	subject, _ = parseEmail(t, server.received()[2].data)
+	if subject != "Datacenter Outage acknowledged" {
+		t.Errorf("expected per-state subject, got %q", subject)
+	}
+
+	notification.Recipients = []models.User{{ID: "alice", Email: "alice@example.com"}}
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if recipients := server.received()[4].recipients; len(recipients) != 1 || !strings.Contains(recipients[0], "alice@example.com") {
+		t.Errorf("expected on-call recipient instead of configured addresses, got %v", recipients)
+	}
+}
+
+// TestEmailNotifier_StartTLSRequired verifies delivery fails when the relay lacks STARTTLS.
+func TestEmailNotifier_StartTLSRequired(t *testing.T) {
+	server := newFakeSMTPServer(t)
+
+	notifier, _ := notify.NewEmailNotifier(notify.EmailConfig{
+		Host:     "127.0.0.1",
+		Port:     server.port(),
+		StartTLS: true,
+		From:     "alarms@example.com",
+		To:       []string{"a@example.com"},
+	})
+
+	err := notifier.Notify(context.Background(), models.Notification{Alarm: models.Alarm{ID: "a1"}})
+	if err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+		t.Errorf("expected STARTTLS error, got %v", err)
+	}
+	if len(server.received()) != 0 {
+		t.Errorf("expected no message to be sent")
+	}
+}
+
+// TestNewEmailNotifier_InvalidTemplate verifies template errors are reported at construction.
+func TestNewEmailNotifier_InvalidTemplate(t *testing.T) {
+	_, err := notify.NewEmailNotifier(notify.EmailConfig{
+		Host:      "127.0.0.1",
+		Port:      25,
+		From:      "alarms@example.com",
+		To:        []string{"a@example.com"},
+		Templates: notify.EmailTemplates{Subject: "{{.Alarm.Name"},
+	})
+	if err == nil {
+		t.Errorf("expected error for an invalid subject template")
+	}
+}
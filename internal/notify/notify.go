@@ -0,0 +1,116 @@
+// Package notify delivers alarm notifications to external channels.
+package notify
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// Notifier delivers alarm notifications to a single channel.
+type Notifier interface {
+	// Name uniquely identifies the notifier within a Registry.
+	Name() string
+	// Notify delivers the notification, returning an error if delivery failed.
+	Notify(ctx context.Context, notification models.Notification) error
+}
+
+// Registry holds the notifiers that alarm notifications are fanned out to.
+type Registry struct {
+	lock      sync.RWMutex
+	notifiers []Notifier
+}
+
+// NewRegistry initializes and returns a Registry containing the given notifiers.
+func NewRegistry(notifiers ...Notifier) (*Registry, error) {
+	registry := &Registry{}
+	for _, notifier := range notifiers {
+		if err := registry.Register(notifier); err != nil {
+			return nil, err
+		}
+	}
+	return registry, nil
+}
+
+// Register adds a notifier to the registry. Notifier names must be unique.
+func (r *Registry) Register(notifier Notifier) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, existing := range r.notifiers {
+		if existing.Name() == notifier.Name() {
+			return fmt.Errorf("notifier %q already registered", notifier.Name())
+		}
+	}
+	r.notifiers = append(r.notifiers, notifier)
+	return nil
+}
+
+// Unregister removes the notifier with the given name, reporting whether it was registered.
+func (r *Registry) Unregister(name string) bool {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for i, existing := range r.notifiers {
+		if existing.Name() == name {
+			r.notifiers = append(r.notifiers[:i], r.notifiers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Notifiers returns a snapshot of the registered notifiers in registration order.
+func (r *Registry) Notifiers() []Notifier {
+	r.lock.RLock()
+	defer r.lock.RUnlock()
+
+	return append([]Notifier{}, r.notifiers...)
+}
+
+// ConsoleNotifier writes notifications as single lines to an io.Writer.
+type ConsoleNotifier struct {
+	lock sync.Mutex
+	out  io.Writer
+}
+
+// NewConsoleNotifier initializes a ConsoleNotifier writing to out, or to stdout if out is nil.
+func NewConsoleNotifier(out io.Writer) *ConsoleNotifier {
+	if out == nil {
+		out = os.Stdout
+	}
+	return &ConsoleNotifier{out: out}
+}
+
+// Name returns the notifier name.
+func (c *ConsoleNotifier) Name() string {
+	return "console"
+}
+
+// Notify writes the notification to the configured writer.
+func (c *ConsoleNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	alarm := notification.Alarm
+	acked := ""
+	if alarm.ACKedBy != "" {
+		acked = fmt.Sprintf(" - ACKed by: %s", alarm.ACKedBy)
+	}
+	recipients := ""
+	if len(notification.Recipients) > 0 {
+		ids := make([]string, 0, len(notification.Recipients))
+		for _, user := range notification.Recipients {
+			ids = append(ids, user.ID)
+		}
+		recipients = fmt.Sprintf(" - Recipients: %s", strings.Join(ids, ", "))
+	}
+	_, err := fmt.Fprintf(c.out, "🔔 Notification for Alarm ID: %s - Severity: %s - State: %s (%s) - Reason: %s%s%s\n",
+		alarm.ID, alarm.Severity, alarm.State, alarm.ISAState, notification.Reason, acked, recipients)
+	return err
+}
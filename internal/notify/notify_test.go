@@ -0,0 +1,89 @@
+package notify_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
+)
+
+// TestRegistry_Register verifies notifiers are kept in order and names must be unique.
+func TestRegistry_Register(t *testing.T) {
+	registry, err := notify.NewRegistry(notify.NewConsoleNotifier(nil))
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if err := registry.Register(notify.NewConsoleNotifier(nil)); err == nil {
+		t.Errorf("expected error registering a duplicate notifier name")
+	}
+	if len(registry.Notifiers()) != 1 {
+		t.Errorf("expected 1 notifier, got %d", len(registry.Notifiers()))
+	}
+
+	if !registry.Unregister("console") {
+		t.Errorf("expected console notifier to be unregistered")
+	}
+	if len(registry.Notifiers()) != 0 {
+		t.Errorf("expected no notifiers, got %d", len(registry.Notifiers()))
+	}
+}
+
+// TestConsoleNotifier_Notify verifies the console notifier writes one line per notification.
+func TestConsoleNotifier_Notify(t *testing.T) {
+	var out bytes.Buffer
+	notifier := notify.NewConsoleNotifier(&out)
+
+	notification := models.Notification{
+		Alarm:  models.Alarm{ID: "a1", Severity: models.Critical, State: models.Triggered, ISAState: models.ISAUnacknowledged},
+		Reason: models.ReasonCreated,
+	}
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	line := out.String()
+	for _, want := range []string{"Alarm ID: a1", "Severity: Critical", "Reason: created"} {
+		if !strings.Contains(line, want) {
+			t.Errorf("expected output to contain %q, got %q", want, line)
+		}
+	}
+}
+
+// TestConsoleNotifier_AckedBy verifies the acknowledging user is included in the notification.
+func TestConsoleNotifier_AckedBy(t *testing.T) {
+	var out bytes.Buffer
+	notifier := notify.NewConsoleNotifier(&out)
+
+	notification := models.Notification{
+		Alarm:  models.Alarm{ID: "a1", State: models.ACKed, ACKedBy: "operator-1"},
+		Reason: models.ReasonAcknowledged,
+	}
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if line := out.String(); !strings.Contains(line, "ACKed by: operator-1") {
+		t.Errorf("expected output to name the acknowledging user, got %q", line)
+	}
+}
+
+// TestConsoleNotifier_Recipients verifies the on-call users paged are included in the notification.
+func TestConsoleNotifier_Recipients(t *testing.T) {
+	var out bytes.Buffer
+	notifier := notify.NewConsoleNotifier(&out)
+
+	notification := models.Notification{
+		Alarm:      models.Alarm{ID: "a1", State: models.Triggered},
+		Reason:     models.ReasonCreated,
+		Recipients: []models.User{{ID: "alice"}, {ID: "bob"}},
+	}
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if line := out.String(); !strings.Contains(line, "Recipients: alice, bob") {
+		t.Errorf("expected output to name the recipients, got %q", line)
+	}
+}
@@ -0,0 +1,222 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+const (
+	// SignatureHeader carries the hex-encoded HMAC-SHA256 signature of a webhook payload.
+	SignatureHeader = "X-Alarm-Signature"
+	// TimestampHeader carries the Unix timestamp (seconds) included in the signature.
+	TimestampHeader = "X-Alarm-Timestamp"
+
+	defaultWebhookRetries   = 5
+	defaultWebhookBackoff   = 500 * time.Millisecond
+	defaultWebhookMaxWait   = 30 * time.Second
+	defaultWebhookTimeout   = 10 * time.Second
+	signaturePrefix         = "sha256="
+	maxWebhookResponseBytes = 4096
+)
+
+// WebhookConfig configures a WebhookNotifier.
+type WebhookConfig struct {
+	Name           string        // Notifier name, defaults to "webhook"
+	URLs           []string      // Endpoints every notification is POSTed to
+	Secret         string        // HMAC-SHA256 signing secret, required unless Unsigned is set
+	Unsigned       bool          // Send payloads without a signature; Secret must then be empty
+	MaxRetries     *int          // Retries after the first attempt, defaults to 5 when nil; 0 disables retries
+	InitialBackoff time.Duration // Base delay before the first retry, defaults to 500ms
+	MaxBackoff     time.Duration // Upper bound of a single retry delay, defaults to 30s
+	Client         *http.Client  // HTTP client, defaults to one with a 10s timeout
+}
+
+// WebhookPayload is the JSON body POSTed to webhook receivers.
+type WebhookPayload struct {
+	Alarm             models.Alarm              `json:"alarm"`
+	PreviousState     models.AlarmState         `json:"previous_state,omitempty"`
+	Reason            models.NotificationReason `json:"reason"`
+	NotificationCount int                       `json:"notification_count"`
+	Recipients        []models.User             `json:"recipients,omitempty"` // On-call users paged by the notification
+	SentAt            string                    `json:"sent_at"`
+}
+
+// WebhookNotifier POSTs signed JSON notifications to one or more URLs, retrying
+// failed deliveries with exponential backoff and jitter.
+type WebhookNotifier struct {
+	config     WebhookConfig
+	maxRetries int
+}
+
+// NewWebhookNotifier initializes a WebhookNotifier, applying defaults to unset config fields.
+func NewWebhookNotifier(config WebhookConfig) (*WebhookNotifier, error) {
+	if len(config.URLs) == 0 {
+		return nil, errors.New("webhook notifier requires at least one URL")
+	}
+	if config.Secret == "" && !config.Unsigned {
+		return nil, errors.New("webhook notifier requires a secret unless unsigned delivery is enabled")
+	}
+	if config.Secret != "" && config.Unsigned {
+		return nil, errors.New("webhook notifier cannot both sign and skip signing payloads")
+	}
+	if config.Name == "" {
+		config.Name = "webhook"
+	}
+	maxRetries := defaultWebhookRetries
+	if config.MaxRetries != nil {
+		if *config.MaxRetries < 0 {
+			return nil, errors.New("webhook notifier retries cannot be negative")
+		}
+		maxRetries = *config.MaxRetries
+	}
+	if config.InitialBackoff <= 0 {
+		config.InitialBackoff = defaultWebhookBackoff
+	}
+	if config.MaxBackoff <= 0 {
+		config.MaxBackoff = defaultWebhookMaxWait
+	}
+	if config.Client == nil {
+		config.Client = &http.Client{Timeout: defaultWebhookTimeout}
+	}
+	return &WebhookNotifier{config: config, maxRetries: maxRetries}, nil
+}
+
+// Name returns the notifier name.
+func (w *WebhookNotifier) Name() string {
+	return w.config.Name
+}
+
+// Notify delivers the notification to every configured URL concurrently, so that
+// a URL being retried does not hold up the others, returning the combined errors
+// of the URLs that could not be reached after all retries.
+func (w *WebhookNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	body, err := json.Marshal(WebhookPayload{
+		Alarm:             notification.Alarm,
+		PreviousState:     notification.PreviousState,
+		Reason:            notification.Reason,
+		NotificationCount: notification.Count,
+		Recipients:        notification.Recipients,
+		SentAt:            time.Now().Format(time.RFC3339),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	errs := make([]error, len(w.config.URLs))
+	var wg sync.WaitGroup
+	for i, url := range w.config.URLs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := w.deliver(ctx, url, body); err != nil {
+				errs[i] = fmt.Errorf("%s: %w", url, err)
+			}
+		}()
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// deliver POSTs body to url, retrying transient failures until MaxRetries is exhausted.
+func (w *WebhookNotifier) deliver(ctx context.Context, url string, body []byte) error {
+	var err error
+	for attempt := 0; attempt <= w.maxRetries; attempt++ {
+		if attempt > 0 {
+			timer := time.NewTimer(w.backoff(attempt))
+			select {
+			case <-ctx.Done():
+				timer.Stop()
+				return fmt.Errorf("giving up after %d attempts: %w", attempt, errors.Join(err, ctx.Err()))
+			case <-timer.C:
+			}
+		}
+
+		var retry bool
+		retry, err = w.post(ctx, url, body)
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", w.maxRetries+1, err)
+}
+
+// post performs a single signed delivery attempt and reports whether a failure is retryable.
+func (w *WebhookNotifier) post(ctx context.Context, url string, body []byte) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(TimestampHeader, timestamp)
+	if !w.config.Unsigned {
+		req.Header.Set(SignatureHeader, Sign(w.config.Secret, timestamp, body))
+	}
+
+	resp, err := w.config.Client.Do(req)
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, maxWebhookResponseBytes))
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return false, nil
+	case resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500:
+		return true, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	default:
+		return false, fmt.Errorf("unexpected status %d", resp.StatusCode)
+	}
+}
+
+// backoff returns the delay before the given retry attempt: an exponentially growing
+// delay capped at MaxBackoff, of which a random half is applied as jitter.
+func (w *WebhookNotifier) backoff(attempt int) time.Duration {
+	delay := w.config.InitialBackoff << (attempt - 1)
+	if delay <= 0 || delay > w.config.MaxBackoff {
+		delay = w.config.MaxBackoff
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// Sign returns the signature header value for a webhook payload: the hex-encoded
+// HMAC-SHA256 of "<timestamp>.<body>" keyed with secret, prefixed with "sha256=".
+func Sign(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return signaturePrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// VerifySignature reports whether signature is valid for the given payload and
+// the timestamp lies within tolerance of now. A zero tolerance skips the age check.
+func VerifySignature(secret, timestamp, signature string, body []byte, tolerance time.Duration) bool {
+	if tolerance > 0 {
+		seconds, err := strconv.ParseInt(timestamp, 10, 64)
+		if err != nil {
+			return false
+		}
+		age := time.Since(time.Unix(seconds, 0))
+		if age > tolerance || age < -tolerance {
+			return false
+		}
+	}
+	return hmac.Equal([]byte(Sign(secret, timestamp, body)), []byte(signature))
+}
@@ -0,0 +1,219 @@
+package notify_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
+)
+
+// testNotification returns a notification used by the webhook tests.
+func testNotification() models.Notification {
+	return models.Notification{
+		Alarm:         models.Alarm{ID: "a1", Name: "Datacenter Outage", State: models.ACKed, Severity: models.Critical},
+		PreviousState: models.Triggered,
+		Reason:        models.ReasonStateChanged,
+		Count:         3,
+		Recipients:    []models.User{{ID: "alice", Name: "Alice"}},
+	}
+}
+
+// retries returns a pointer to a webhook retry count.
+func retries(n int) *int {
+	return &n
+}
+
+// TestWebhookNotifier_SignedDelivery verifies payload content and a verifiable HMAC signature.
+func TestWebhookNotifier_SignedDelivery(t *testing.T) {
+	const secret = "s3cr3t"
+	var received notify.WebhookPayload
+	var verified atomic.Bool
+
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		verified.Store(notify.VerifySignature(secret, r.Header.Get(notify.TimestampHeader), r.Header.Get(notify.SignatureHeader), body, time.Minute))
+		json.Unmarshal(body, &received)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	notifier, err := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{receiver.URL}, Secret: secret})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := notifier.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	if !verified.Load() {
+		t.Errorf("expected receiver to verify the signature")
+	}
+	if received.Alarm.ID != "a1" || received.PreviousState != models.Triggered || received.Reason != models.ReasonStateChanged || received.NotificationCount != 3 ||
+		len(received.Recipients) != 1 || received.Recipients[0].ID != "alice" {
+		t.Errorf("unexpected payload %+v", received)
+	}
+}
+
+// TestWebhookNotifier_Unsigned verifies a secret is required unless unsigned delivery is enabled,
+// which sends no signature.
+func TestWebhookNotifier_Unsigned(t *testing.T) {
+	var signature atomic.Value
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		signature.Store(r.Header.Get(notify.SignatureHeader))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	if _, err := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{receiver.URL}}); err == nil {
+		t.Errorf("expected error for a webhook without a secret")
+	}
+	if _, err := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{receiver.URL}, Secret: "s3cr3t", Unsigned: true}); err == nil {
+		t.Errorf("expected error for an unsigned webhook with a secret")
+	}
+
+	notifier, err := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{receiver.URL}, Unsigned: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := notifier.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if signature.Load() != "" {
+		t.Errorf("expected no signature header, got %q", signature.Load())
+	}
+}
+
+// TestWebhookNotifier_RetriesTransientFailures verifies 5xx responses are retried until success.
+func TestWebhookNotifier_RetriesTransientFailures(t *testing.T) {
+	var attempts atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if attempts.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer receiver.Close()
+
+	notifier, _ := notify.NewWebhookNotifier(notify.WebhookConfig{
+		URLs:           []string{receiver.URL},
+		Unsigned:       true,
+		MaxRetries:     retries(4),
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     5 * time.Millisecond,
+	})
+	if err := notifier.Notify(context.Background(), testNotification()); err != nil {
+		t.Fatalf("expected delivery to succeed after retries, got %v", err)
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+// TestWebhookNotifier_GivesUp verifies client errors are not retried and exhausted retries are reported.
+func TestWebhookNotifier_GivesUp(t *testing.T) {
+	var attempts atomic.Int32
+	status := http.StatusBadRequest
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(status)
+	}))
+	defer receiver.Close()
+
+	notifier, _ := notify.NewWebhookNotifier(notify.WebhookConfig{
+		URLs:           []string{receiver.URL},
+		Unsigned:       true,
+		MaxRetries:     retries(2),
+		InitialBackoff: time.Millisecond,
+		MaxBackoff:     time.Millisecond,
+	})
+
+	if err := notifier.Notify(context.Background(), testNotification()); err == nil {
+		t.Errorf("expected error for 400 response")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected 400 to not be retried, got %d attempts", attempts.Load())
+	}
+
+	attempts.Store(0)
+	status = http.StatusInternalServerError
+	if err := notifier.Notify(context.Background(), testNotification()); err == nil {
+		t.Errorf("expected error after exhausting retries")
+	}
+	if attempts.Load() != 3 {
+		t.Errorf("expected 3 attempts, got %d", attempts.Load())
+	}
+}
+
+// TestWebhookNotifier_NoRetries verifies an explicit retry count of zero disables retries.
+func TestWebhookNotifier_NoRetries(t *testing.T) {
+	var attempts atomic.Int32
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts.Add(1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer receiver.Close()
+
+	notifier, err := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{receiver.URL}, Unsigned: true, MaxRetries: retries(0)})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := notifier.Notify(context.Background(), testNotification()); err == nil {
+		t.Errorf("expected error for 503 response")
+	}
+	if attempts.Load() != 1 {
+		t.Errorf("expected a single attempt, got %d", attempts.Load())
+	}
+
+	if _, err := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{receiver.URL}, Unsigned: true, MaxRetries: retries(-1)}); err == nil {
+		t.Errorf("expected error for a negative retry count")
+	}
+}
+
+// TestWebhookNotifier_ConcurrentDelivery verifies a slow URL does not hold up delivery to the others.
+func TestWebhookNotifier_ConcurrentDelivery(t *testing.T) {
+	delivered := make(chan struct{})
+	slow := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-delivered:
+			w.WriteHeader(http.StatusOK)
+		case <-time.After(time.Second):
+			w.WriteHeader(http.StatusGatewayTimeout)
+		}
+	}))
+	defer slow.Close()
+	fast := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(delivered)
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer fast.Close()
+
+	notifier, _ := notify.NewWebhookNotifier(notify.WebhookConfig{URLs: []string{slow.URL, fast.URL}, Unsigned: true, MaxRetries: retries(0)})
+	if err := notifier.Notify(context.Background(), testNotification()); err != nil {
+		t.Errorf("expected the second URL to be delivered while the first was pending, got %v", err)
+	}
+}
+
+// TestVerifySignature verifies tampered bodies and stale timestamps are rejected.
+func TestVerifySignature(t *testing.T) {
+	body := []byte(`{"reason":"created"}`)
+	timestamp := "1700000000"
+	signature := notify.Sign("secret", timestamp, body)
+
+	if !notify.VerifySignature("secret", timestamp, signature, body, 0) {
+		t.Errorf("expected valid signature to verify")
+	}
+	if notify.VerifySignature("secret", timestamp, signature, []byte(`{"reason":"tampered"}`), 0) {
+		t.Errorf("expected tampered body to fail verification")
+	}
+	if notify.VerifySignature("secret", timestamp, signature, body, time.Minute) {
+		t.Errorf("expected stale timestamp to fail verification")
+	}
+}
@@ -1,148 +1,458 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"log"
+	"slices"
 	"sync"
 	"time"
 
 	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
 	"github.com/google/uuid"
 )
 
+// maxDeliveryResults bounds the number of delivery results retained per alarm.
+const maxDeliveryResults = 20
+
+// DefaultShutdownTimeout bounds the time Run waits for pending notifications when its
+// context is cancelled, unless overridden with WithShutdownTimeout.
+const DefaultShutdownTimeout = 30 * time.Second
+
+// notifyTimeout bounds the time a single notifier may take to deliver a notification,
+// including any retries it performs.
+const notifyTimeout = 5 * time.Minute
+
 // AlarmService manages alarm operations with thread safety and notification support.
 type AlarmService struct {
-	alarms               map[string]models.Alarm
-	lock                 sync.RWMutex
-	notifyChan           chan models.Alarm
-	notificationSchedule map[string]time.Time
+	store     store.AlarmStore
+	lock      sync.RWMutex
+	notifiers *notify.Registry
+	clock     Clock
+	scheduler *scheduler
+	closed    bool
+
+	reopenWindow time.Duration
+	dedupIndex   map[string]string // Fingerprint to ID of the latest instance of the alarm
+	labelIndex   *labelIndex
+
+	inhibitRules []models.InhibitRule
+	sources      map[string]models.Alarm // Alarms that currently inhibit the targets of a rule, by ID
+	targets      map[string]struct{}     // IDs of alarms a rule may inhibit or that are inhibited
+
+	escalationPolicies []models.EscalationPolicy
+	escalations        *scheduler // Escalation due times of unacknowledged alarms
+
+	routes *models.Route // Routing tree, nil to deliver to every notifier
+
+	users        map[string]models.User
+	schedules    map[string]models.Schedule
+	overrideLock sync.RWMutex
+	overrides    map[string]models.Override // Mirror of the stored on-call overrides, read when notifying
+
+	silenceLock sync.RWMutex
+	silences    map[string]models.Silence // Mirror of the stored silences, read when notifying
+
+	ctx             context.Context // Cancelled to abort in-flight deliveries
+	cancel          context.CancelFunc
+	workers         sync.WaitGroup
+	shutdownTimeout time.Duration
+
+	outbox         *boundedQueue[models.Notification]
+	queueSize      int
+	queueLock      sync.Mutex
+	notifierQueues map[string]*boundedQueue[delivery]
+
+	deliveryLock sync.RWMutex
+	deliveries   map[string]*models.DeliveryStatus
 }
 
-// NewAlarmService initializes and returns a new AlarmService instance.
-func NewAlarmService() *AlarmService {
-	svc := &AlarmService{
-		alarms:               make(map[string]models.Alarm),
-		notifyChan:           make(chan models.Alarm, 100),
-		notificationSchedule: make(map[string]time.Time),
+// Option configures optional AlarmService dependencies.
+type Option func(*AlarmService)
+
+// WithStore sets the AlarmStore used to persist alarms and their notification schedule.
+// The in-memory store is used when this option is omitted.
+func WithStore(st store.AlarmStore) Option {
+	return func(s *AlarmService) {
+		s.store = st
 	}
+}
 
-	go svc.startNotificationHandler()
-	go svc.startScheduler()
-	return svc
+// WithNotifiers sets the registry of notifiers that alarm notifications are fanned out to.
+// A registry containing only a ConsoleNotifier is used when this option is omitted.
+func WithNotifiers(registry *notify.Registry) Option {
+	return func(s *AlarmService) {
+		s.notifiers = registry
+	}
 }
 
-// NotificationInterval defines intervals for sending notifications based on alarm state.
-type NotificationInterval struct {
-	Interval time.Duration
+// WithClock sets the Clock used for timestamps and notification scheduling.
+// The system clock is used when this option is omitted.
+func WithClock(clock Clock) Option {
+	return func(s *AlarmService) {
+		s.clock = clock
+	}
 }
 
-// stateNotificationIntervals manages notification intervals per alarm state.
-var stateNotificationIntervals = map[models.AlarmState]NotificationInterval{
-	models.Triggered: {Interval: 2 * time.Hour},
-	models.ACKed:     {Interval: 24 * time.Hour},
+// WithNotificationQueueSize bounds the number of notifications pending in the outbox and
+// in each notifier queue. Notifications queued beyond this limit are dropped and reported
+// by NotificationStats. Defaults to 10000.
+func WithNotificationQueueSize(size int) Option {
+	return func(s *AlarmService) {
+		s.queueSize = size
+	}
 }
 
-// startNotificationHandler continuously processes alarm notifications.
-func (s *AlarmService) startNotificationHandler() {
-	for alarm := range s.notifyChan {
-		s.processNotification(alarm)
+// WithShutdownTimeout bounds the time Run waits for pending notifications to be
+// delivered once its context is cancelled. Defaults to 30s.
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *AlarmService) {
+		s.shutdownTimeout = timeout
 	}
 }
 
-// startScheduler continuously checks scheduled alarms and triggers them automatically.
-func (s *AlarmService) startScheduler() {
-	ticker := time.NewTicker(1 * time.Hour)
-	defer ticker.Stop()
+// WithReopenWindow sets how long after being cleared an alarm is reopened when its source
+// sends it again. Repeats arriving later raise a new instance of the alarm, as do all
+// repeats when window is zero. Defaults to 15 minutes.
+func WithReopenWindow(window time.Duration) Option {
+	return func(s *AlarmService) {
+		s.reopenWindow = window
+	}
+}
 
-	for range ticker.C {
-		s.checkAndTriggerNotifications()
+// NewAlarmService initializes and returns a new AlarmService instance, or an error if the
+// options configure invalid escalation policies.
+// The notification scheduler is seeded with the schedule persisted in the store.
+func NewAlarmService(opts ...Option) (*AlarmService, error) {
+	svc := &AlarmService{
+		notifierQueues: make(map[string]*boundedQueue[delivery]),
+		deliveries:     make(map[string]*models.DeliveryStatus),
+		reopenWindow:   DefaultReopenWindow,
+		users:          make(map[string]models.User),
+		schedules:      make(map[string]models.Schedule),
+	}
+	for _, opt := range opts {
+		opt(svc)
+	}
+	if err := models.ValidateEscalationPolicies(svc.escalationPolicies); err != nil {
+		return nil, fmt.Errorf("invalid escalation policies: %w", err)
+	}
+	if svc.store == nil {
+		svc.store = store.NewMemoryStore()
+	}
+	if svc.notifiers == nil {
+		svc.notifiers, _ = notify.NewRegistry(notify.NewConsoleNotifier(nil))
 	}
+	if svc.clock == nil {
+		svc.clock = realClock{}
+	}
+	if svc.queueSize <= 0 {
+		svc.queueSize = defaultNotificationQueueSize
+	}
+	if svc.shutdownTimeout <= 0 {
+		svc.shutdownTimeout = DefaultShutdownTimeout
+	}
+	svc.dedupIndex = buildDedupIndex(svc.store.List())
+	svc.labelIndex = newLabelIndex(svc.store.List())
+	svc.indexInhibitions(svc.store.List())
+	svc.silences = make(map[string]models.Silence)
+	for _, silence := range svc.store.Silences() {
+		svc.silences[silence.ID] = silence
+	}
+	svc.overrides = make(map[string]models.Override)
+	for _, override := range svc.store.Overrides() {
+		svc.overrides[override.ID] = override
+	}
+	svc.ctx, svc.cancel = context.WithCancel(context.Background())
+	svc.outbox = newBoundedQueue[models.Notification]("outbox", svc.queueSize)
+
+	svc.scheduler = newScheduler(svc.clock, svc.checkAndTriggerNotifications)
+	for id, at := range svc.store.Schedule() {
+		svc.scheduler.schedule(id, at)
+	}
+	svc.escalations = newScheduler(svc.clock, svc.escalateAlarms)
+	for _, alarm := range svc.store.List() {
+		svc.scheduleEscalation(alarm)
+	}
+	svc.reconcileInhibitions()
+
+	svc.workers.Add(1)
+	go func() {
+		defer svc.workers.Done()
+		svc.outbox.drain(svc.processNotification)
+		svc.closeNotifierQueues()
+	}()
+	svc.workers.Add(1)
+	go func() {
+		defer svc.workers.Done()
+		svc.scheduler.run()
+	}()
+	svc.workers.Add(1)
+	go func() {
+		defer svc.workers.Done()
+		svc.escalations.run()
+	}()
+	return svc, nil
+}
+
+// Run blocks until ctx is cancelled and then closes the service, allowing pending
+// notifications up to the shutdown timeout to be delivered.
+func (s *AlarmService) Run(ctx context.Context) error {
+	<-ctx.Done()
+
+	closeCtx, cancel := context.WithTimeout(context.Background(), s.shutdownTimeout)
+	defer cancel()
+	return s.Close(closeCtx)
+}
+
+// Close stops the schedulers, rejects further mutations with ErrClosed, waits for queued
+// notifications to be delivered and closes the store. If ctx expires first, in-flight
+// deliveries are cancelled, undelivered notifications are discarded and ctx.Err() is
+// returned; the store is closed either way. Closing a closed service is a no-op.
+func (s *AlarmService) Close(ctx context.Context) error {
+	s.lock.Lock()
+	if s.closed {
+		s.lock.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.lock.Unlock()
+
+	s.scheduler.stop()
+	s.escalations.stop()
+	s.outbox.close()
+
+	drained := make(chan struct{})
+	go func() {
+		s.workers.Wait()
+		close(drained)
+	}()
+
+	var drainErr error
+	select {
+	case <-drained:
+	case <-ctx.Done():
+		drainErr = ctx.Err()
+	}
+	s.cancel()
+
+	return errors.Join(drainErr, s.store.Close())
+}
+
+// NotificationInterval defines intervals for sending notifications based on alarm state.
+type NotificationInterval struct {
+	Interval time.Duration
+}
+
+// defaultSeverity is assigned to alarms created without a severity.
+const defaultSeverity = models.Minor
+
+// stateNotificationIntervals manages notification intervals per combined ISA-18.2 alarm state
+// and severity. States or severities without an entry are not reminded.
+var stateNotificationIntervals = map[models.ISAState]map[models.Severity]NotificationInterval{
+	models.ISAUnacknowledged: {
+		models.Critical: {Interval: 5 * time.Minute},
+		models.Major:    {Interval: 30 * time.Minute},
+		models.Minor:    {Interval: 2 * time.Hour},
+		models.Warning:  {Interval: 4 * time.Hour},
+		models.Info:     {Interval: 12 * time.Hour},
+	},
+	models.ISAAcknowledged: {
+		models.Critical: {Interval: 1 * time.Hour},
+		models.Major:    {Interval: 4 * time.Hour},
+		models.Minor:    {Interval: 24 * time.Hour},
+		models.Warning:  {Interval: 24 * time.Hour},
+	},
+	models.ISAReturnedUnacked: {
+		models.Critical: {Interval: 1 * time.Hour},
+		models.Major:    {Interval: 4 * time.Hour},
+		models.Minor:    {Interval: 24 * time.Hour},
+		models.Warning:  {Interval: 24 * time.Hour},
+	},
 }
 
-// checkAndTriggerNotifications identifies alarms that are due for notification.
-func (s *AlarmService) checkAndTriggerNotifications() {
+// notificationInterval returns the reminder interval for an alarm based on its combined state and severity.
+func notificationInterval(alarm models.Alarm) (NotificationInterval, bool) {
+	intervalData, exists := stateNotificationIntervals[alarm.ISAState][alarm.Severity]
+	return intervalData, exists
+}
+
+// checkAndTriggerNotifications is called by the scheduler with the IDs of alarms whose
+// reminder or shelve expiry is due. It unshelves expired alarms, and reschedules and
+// queues a reminder notification for the others.
+func (s *AlarmService) checkAndTriggerNotifications(ids []string) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	now := time.Now()
-	for id, nextNotifyTime := range s.notificationSchedule {
-		if now.After(nextNotifyTime) {
-			if alarm, found := s.alarms[id]; found {
-	/*	
-				// Commented this code as it is not part of requirement.
-				// This logic about, in case alarm manually not acknowledged 
-				// also by default acknoledged in 24 Hours	
-				if alarm.State == models.Triggered {
-					createdAt, err := s.getCreatedAtTime(alarm)
-					if err == nil && now.Sub(createdAt) >= stateNotificationIntervals[models.ACKed].Interval {
-						alarm.State = models.ACKed
-						alarm.ACKedAt = now.Format(time.RFC3339)
-					}
+	if s.closed {
+		return
+	}
+
+	now := s.clock.Now()
+	for _, id := range ids {
+		nextNotifyTime, scheduled := s.store.NextNotification(id)
+		if scheduled && !nextNotifyTime.After(now) {
+			if alarm, found := s.store.Get(id); found && alarm.Suppression == models.Shelved {
+				if _, err := s.unshelve(Audit{Via: schedulerVia}, alarm, now); err != nil {
+					log.Printf("failed to unshelve alarm %s: %v", alarm.ID, err)
 				}
-	*/
-				intervalData, exists := stateNotificationIntervals[alarm.State]
-				if exists {
-					s.notificationSchedule[alarm.ID] = now.Add(intervalData.Interval)
+			} else if found {
+				if err := s.apply(s.scheduleOp(alarm, now)); err != nil {
+					log.Printf("failed to reschedule alarm %s: %v", alarm.ID, err)
 				}
 
-				s.notifyChan <- alarm 
+				s.notify(models.Notification{Alarm: alarm, Reason: models.ReasonReminder})
 			}
 		}
 	}
 }
 
-// processNotification handles sending notifications with appropriate intervals.
-func (s *AlarmService) processNotification(alarm models.Alarm) {
-	s.lock.Lock()
-	defer s.lock.Unlock()
+// processNotification fans a notification out to the queues of its receivers for alarms whose
+// combined state is reminded: the targets of the current escalation tier for alarms with an
+// escalation policy, and otherwise the receivers of the routes the alarm matches. Schedule
+// receivers are resolved to the users on call at send time, who are passed to the notifiers
+// that page them as recipients. It runs on the outbox worker, outside the service lock, and
+// each notifier queue is drained by its own worker so that slow or retrying notifiers never
+// block the outbox or each other.
+// The next reminder is scheduled by the mutation that queued the notification, so a
+// stale notification processed late never overrides the current schedule.
+func (s *AlarmService) processNotification(notification models.Notification) {
+	if _, exists := notificationInterval(notification.Alarm); !exists {
+		return
+	}
+
+	notification.Count = s.nextNotificationCount(notification.Alarm.ID)
+	targets := s.notificationTargets(notification.Alarm)
+	recipients := s.onCallRecipients(targets)
+	for _, notifier := range s.notifiers.Notifiers() {
+		if !slices.Contains(targets, notifier.Name()) && recipients[notifier.Name()] == nil {
+			continue
+		}
+		addressed := notification
+		addressed.Recipients = recipients[notifier.Name()]
+		s.notifierQueue(notifier.Name()).push(delivery{notifier: notifier, notification: addressed})
+	}
+}
+
+// deliver sends a notification through a single notifier and records the delivery result.
+func (s *AlarmService) deliver(notifier notify.Notifier, notification models.Notification) {
+	ctx, cancel := context.WithTimeout(s.ctx, notifyTimeout)
+	defer cancel()
+
+	err := notifier.Notify(ctx, notification)
+	result := models.DeliveryResult{
+		Notifier: notifier.Name(),
+		Reason:   notification.Reason,
+		Success:  err == nil,
+		SentAt:   s.clock.Now().Format(time.RFC3339),
+	}
+	if err != nil {
+		log.Printf("notifier %s failed for alarm %s: %v", notifier.Name(), notification.Alarm.ID, err)
+		result.Error = err.Error()
+	}
+	s.recordDelivery(notification.Alarm.ID, result)
+}
 
-	intervalData, exists := stateNotificationIntervals[alarm.State]
+// nextNotificationCount increments and returns the number of notifications processed for an alarm.
+func (s *AlarmService) nextNotificationCount(id string) int {
+	s.deliveryLock.Lock()
+	defer s.deliveryLock.Unlock()
+
+	status, exists := s.deliveries[id]
 	if !exists {
-		return
+		status = &models.DeliveryStatus{AlarmID: id}
+		s.deliveries[id] = status
 	}
+	status.NotificationCount++
+	return status.NotificationCount
+}
 
-	fmt.Printf("🔔 Notification for Alarm ID: %s - State: %s\n", alarm.ID, alarm.State)
-	s.notificationSchedule[alarm.ID] = time.Now().Add(intervalData.Interval)
+// recordDelivery appends a delivery result for an alarm, keeping at most maxDeliveryResults.
+func (s *AlarmService) recordDelivery(id string, result models.DeliveryResult) {
+	s.deliveryLock.Lock()
+	defer s.deliveryLock.Unlock()
+
+	status, exists := s.deliveries[id]
+	if !exists {
+		return
+	}
+	status.Results = append(status.Results, result)
+	if len(status.Results) > maxDeliveryResults {
+		status.Results = status.Results[len(status.Results)-maxDeliveryResults:]
+	}
 }
 
-// CreateAlarm creates a new alarm with default values and triggers notification if applicable.
-func (s *AlarmService) CreateAlarm(alarm models.Alarm) (models.Alarm, error) {
-	if err := s.validateAlarm(alarm); err != nil {
-		return models.Alarm{}, err
+// GetNextNotification returns when the next reminder for an alarm is due. The due
+// time is left empty when the alarm has no pending reminder in its current state.
+func (s *AlarmService) GetNextNotification(id string) (models.NotificationSchedule, error) {
+	if _, err := s.GetAlarmByID(id); err != nil {
+		return models.NotificationSchedule{}, err
 	}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+	schedule := models.NotificationSchedule{AlarmID: id}
+	if at, scheduled := s.scheduler.next(id); scheduled {
+		schedule.NextNotificationAt = at.Format(time.RFC3339)
+	}
+	return schedule, nil
+}
+
+// GetDeliveryStatus returns the notification count and recent delivery results for an alarm.
+func (s *AlarmService) GetDeliveryStatus(id string) (models.DeliveryStatus, error) {
+	if _, err := s.GetAlarmByID(id); err != nil {
+		return models.DeliveryStatus{}, err
+	}
 
-	s.initializeAlarm(&alarm)
-	s.alarms[alarm.ID] = alarm
-	s.notifyChan <- alarm // Notify immediately when created in 'Triggered' state
+	s.deliveryLock.RLock()
+	defer s.deliveryLock.RUnlock()
 
-	return alarm, nil
+	status, exists := s.deliveries[id]
+	if !exists {
+		return models.DeliveryStatus{AlarmID: id, Results: []models.DeliveryResult{}}, nil
+	}
+	return models.DeliveryStatus{
+		AlarmID:           status.AlarmID,
+		NotificationCount: status.NotificationCount,
+		Results:           append([]models.DeliveryResult{}, status.Results...),
+	}, nil
 }
 
-// BulkCreateAlarms handles bulk alarm creation with concurrency safety.
-func (s *AlarmService) BulkCreateAlarms(alarms []models.Alarm) ([]models.Alarm, error) {
-	var createdAlarms []models.Alarm
-	var errorList []string
+// CreateAlarm creates a new alarm with default values and triggers notification if applicable.
+// A repeat of an alarm with the same fingerprint is merged into its latest instance instead,
+// as described by CreateAlarms.
+func (s *AlarmService) CreateAlarm(ctx context.Context, alarm models.Alarm) (models.Alarm, error) {
+	results, err := s.CreateAlarms(ctx, []models.Alarm{alarm}, BulkOptions{})
+	if err != nil {
+		return models.Alarm{}, err
+	}
+	if results[0].Err != nil {
+		return models.Alarm{}, results[0].Err
+	}
+	return results[0].Alarm, nil
+}
 
-	s.lock.Lock()
-	defer s.lock.Unlock()
+// BulkCreateAlarms handles bulk alarm creation with concurrency safety. Alarms that
+// fail validation are skipped and reported in a *BulkError; the others are created.
+func (s *AlarmService) BulkCreateAlarms(ctx context.Context, alarms []models.Alarm) ([]models.Alarm, error) {
+	results, err := s.CreateAlarms(ctx, alarms, BulkOptions{})
+	if err != nil {
+		return nil, err
+	}
 
-	for _, alarm := range alarms {
-		if err := s.validateAlarm(alarm); err != nil {
-			errorList = append(errorList, fmt.Sprintf("Alarm %s: %v", alarm.Name, err))
+	var createdAlarms []models.Alarm
+	var failed []BulkItemError
+	for _, result := range results {
+		if result.Err != nil {
+			failed = append(failed, BulkItemError{Index: result.Index, Name: alarms[result.Index].Name, Err: result.Err})
 			continue
 		}
-
-		s.initializeAlarm(&alarm)
-		s.alarms[alarm.ID] = alarm
-		s.notifyChan <- alarm
-		createdAlarms = append(createdAlarms, alarm)
+		createdAlarms = append(createdAlarms, result.Alarm)
 	}
 
-	if len(errorList) > 0 {
-		return createdAlarms, fmt.Errorf("failed to create some alarms: %v", errorList)
+	if len(failed) > 0 {
+		return createdAlarms, &BulkError{Items: failed}
 	}
 
 	return createdAlarms, nil
@@ -151,21 +461,101 @@ func (s *AlarmService) BulkCreateAlarms(alarms []models.Alarm) ([]models.Alarm,
 // initializeAlarm sets default values for a new alarm.
 func (s *AlarmService) initializeAlarm(alarm *models.Alarm) {
 	alarm.ID = uuid.New().String()
-	alarm.CreatedAt = time.Now().Format(time.RFC3339)
+	alarm.DedupKey = alarm.Fingerprint()
+	alarm.CreatedAt = s.clock.Now().Format(time.RFC3339)
+	alarm.LastSeen = alarm.CreatedAt
+	alarm.Occurrences = 1
 	alarm.State = models.Triggered
-	s.notificationSchedule[alarm.ID] = time.Now().Add(stateNotificationIntervals[models.Triggered].Interval)
+	if alarm.Severity == "" {
+		alarm.Severity = defaultSeverity
+	}
+	alarm.Condition = models.ConditionActive
+	alarm.Acknowledgement = models.Unacknowledged
+	alarm.InhibitedBy = nil
+	*alarm = withoutEscalation(*alarm)
+	alarm.ISAState = alarm.CombinedState()
+}
+
+// createOps returns the store mutations that persist a newly initialized alarm and record its creation.
+func (s *AlarmService) createOps(audit Audit, alarm models.Alarm, now time.Time) []store.Op {
+	return []store.Op{store.PutAlarm(alarm), s.scheduleOp(alarm, now), recordOp(audit, alarm, "", models.ReasonCreated, now)}
+}
+
+// apply persists ops to the store, mirrors any schedule changes into the schedulers, label
+// changes into the label index and silence and override changes into the silences and
+// overrides consulted when notifying, and re-evaluates the inhibition of alarms.
+// It fails with ErrClosed once the service is closed. Callers must hold the write lock.
+func (s *AlarmService) apply(ops ...store.Op) error {
+	if s.closed {
+		return ErrClosed
+	}
+	if err := s.store.Apply(ops...); err != nil {
+		return err
+	}
+
+	for _, op := range ops {
+		switch op.Kind {
+		case store.OpPutAlarm:
+			s.labelIndex.put(*op.Alarm)
+			s.scheduleEscalation(*op.Alarm)
+		case store.OpSchedule:
+			s.scheduler.schedule(op.ID, op.At)
+		case store.OpUnschedule:
+			s.scheduler.cancel(op.ID)
+		case store.OpDeleteAlarm:
+			s.scheduler.cancel(op.ID)
+			s.escalations.cancel(op.ID)
+			s.labelIndex.remove(op.ID)
+		case store.OpPutSilence:
+			s.putSilence(*op.Silence)
+		case store.OpPutOverride:
+			s.putOverride(*op.Override)
+		case store.OpDeleteOverride:
+			s.deleteOverride(op.ID)
+		}
+	}
+	s.updateInhibitions(ops)
+	return nil
 }
 
-// GetAllAlarms retrieves all stored alarms in memory.
+// scheduleOp returns the store mutation that schedules the next reminder for an alarm
+// based on its combined state, or clears it when that state is not reminded. A shelved
+// alarm is scheduled for the expiry of its shelve instead.
+func (s *AlarmService) scheduleOp(alarm models.Alarm, now time.Time) store.Op {
+	if alarm.Suppression == models.Shelved && alarm.ShelvedUntil != "" {
+		if until, err := time.Parse(time.RFC3339, alarm.ShelvedUntil); err == nil {
+			return store.Schedule(alarm.ID, until)
+		}
+	}
+	if intervalData, exists := notificationInterval(alarm); exists {
+		return store.Schedule(alarm.ID, now.Add(intervalData.Interval))
+	}
+	return store.Unschedule(alarm.ID)
+}
+
+// GetAllAlarms retrieves all stored alarms.
 func (s *AlarmService) GetAllAlarms() []models.Alarm {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	alarms := make([]models.Alarm, 0, len(s.alarms))
-	for _, alarm := range s.alarms {
-		alarms = append(alarms, alarm)
+	return s.store.List()
+}
+
+// GetAlarmsBySeverity retrieves all stored alarms matching any of the given severities.
+func (s *AlarmService) GetAlarmsBySeverity(severities ...models.Severity) []models.Alarm {
+	query := models.AlarmQuery{Severities: severities, Limit: maxQueryLimit}
+	alarms := []models.Alarm{}
+	for {
+		page, err := s.ListAlarms(context.Background(), query)
+		if err != nil {
+			return alarms
+		}
+		alarms = append(alarms, page.Alarms...)
+		if page.NextCursor == "" {
+			return alarms
+		}
+		query.Cursor = page.NextCursor
 	}
-	return alarms
 }
 
 // GetAlarmByID retrieves an alarm by its unique ID.
@@ -173,65 +563,294 @@ func (s *AlarmService) GetAlarmByID(id string) (models.Alarm, error) {
 	s.lock.RLock()
 	defer s.lock.RUnlock()
 
-	if alarm, found := s.alarms[id]; found {
+	if alarm, found := s.store.Get(id); found {
 		return alarm, nil
 	}
-	return models.Alarm{}, errors.New("alarm not found")
+	return models.Alarm{}, ErrNotFound
 }
 
 // UpdateAlarmState updates the state of an alarm and triggers a notification if necessary.
-func (s *AlarmService) UpdateAlarmState(id string, state models.AlarmState) (models.Alarm, error) {
+// Only transitions permitted by the alarm lifecycle are accepted; others return a *TransitionError.
+func (s *AlarmService) UpdateAlarmState(ctx context.Context, id string, state models.AlarmState) (models.Alarm, error) {
 	if !state.IsValid() {
-		return models.Alarm{}, errors.New("invalid alarm state")
+		return models.Alarm{}, ErrInvalidState
 	}
 
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if alarm, found := s.alarms[id]; found {
-		alarm.State = state
-		alarm.UpdatedAt = time.Now().Format(time.RFC3339)
+	if alarm, found := s.store.Get(id); found {
+		audit := auditFrom(ctx)
+		now := s.clock.Now()
+		updated, err := withState(alarm, state, audit, now)
+		if err != nil {
+			return models.Alarm{}, err
+		}
+		return s.saveTransition(audit, updated, alarm.State, models.ReasonStateChanged, now)
+	}
+
+	return models.Alarm{}, ErrNotFound
+}
+
+// withState returns alarm moved to the given lifecycle state, mapping the state onto
+// the condition and acknowledgement dimensions, or a *TransitionError if the lifecycle
+// does not allow it. An acknowledgement, including the implicit one of clearing an
+// unacknowledged alarm, is attributed to the actor of audit.
+func withState(alarm models.Alarm, state models.AlarmState, audit Audit, now time.Time) (models.Alarm, error) {
+	if !alarm.State.CanTransitionTo(state) {
+		return models.Alarm{}, &TransitionError{From: alarm.State, To: state}
+	}
 
-		if state == models.ACKed {
-			alarm.ACKedAt = time.Now().Format(time.RFC3339)
+	alarm.State = state
+	switch state {
+	case models.Active:
+		alarm.Condition = models.ConditionActive
+	case models.ACKed:
+		alarm.Acknowledgement = models.Acknowledged
+		alarm.ACKedAt = now.Format(time.RFC3339)
+		alarm.ACKedBy = audit.Actor
+		alarm.AckComment = audit.Comment
+	case models.Cleared:
+		alarm.Condition = models.ConditionNormal
+		if alarm.Acknowledgement != models.Acknowledged {
+			alarm.Acknowledgement = models.Acknowledged
+			alarm.ACKedAt = now.Format(time.RFC3339)
+			alarm.ACKedBy = audit.Actor
 		}
+	}
+	return alarm, nil
+}
 
-		s.alarms[id] = alarm
-		s.notifyChan <- alarm
+// ReopenAlarm moves a Cleared alarm back to Triggered and restarts its notification schedule.
+func (s *AlarmService) ReopenAlarm(ctx context.Context, id string) (models.Alarm, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+	if alarm.State != models.Cleared {
+		return models.Alarm{}, &TransitionError{From: alarm.State, To: models.Triggered}
+	}
+
+	return s.reopen(auditFrom(ctx), alarm, s.clock.Now())
+}
+
+// reopen moves a Cleared alarm back to Triggered with an active, unacknowledged condition.
+// Callers must hold the write lock.
+func (s *AlarmService) reopen(audit Audit, alarm models.Alarm, now time.Time) (models.Alarm, error) {
+	return s.saveTransition(audit, reopened(alarm), alarm.State, models.ReasonReopened, now)
+}
+
+// reopened returns alarm moved back to Triggered with an active, unacknowledged condition,
+// escalating from the first tier again.
+func reopened(alarm models.Alarm) models.Alarm {
+	alarm = withoutEscalation(alarm)
+	alarm.State = models.Triggered
+	alarm.Condition = models.ConditionActive
+	alarm.Acknowledgement = models.Unacknowledged
+	alarm.ACKedAt = ""
+	alarm.ACKedBy = ""
+	alarm.AckComment = ""
+	return alarm
+}
+
+// ReportCondition records a process condition change reported by the alarm source.
+// An active condition on a Cleared alarm reopens it; a return to normal clears an
+// acknowledged alarm and leaves an unacknowledged one awaiting operator acknowledgement.
+func (s *AlarmService) ReportCondition(ctx context.Context, id string, condition models.AlarmCondition) (models.Alarm, error) {
+	if !condition.IsValid() {
+		return models.Alarm{}, &ValidationError{Field: "condition", Message: "invalid alarm condition"}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+
+	now := s.clock.Now()
+	if condition == models.ConditionActive && alarm.State == models.Cleared {
+		return s.reopen(auditFrom(ctx), alarm, now)
+	}
+	if alarm.Condition == condition {
 		return alarm, nil
 	}
 
-	return models.Alarm{}, errors.New("alarm not found")
+	previous := alarm.State
+	alarm.Condition = condition
+	if condition == models.ConditionNormal && alarm.Acknowledgement == models.Acknowledged {
+		alarm.State = models.Cleared
+	}
+	return s.saveTransition(auditFrom(ctx), alarm, previous, models.ReasonConditionChanged, now)
 }
 
-// DeleteAlarm removes an alarm from the in-memory store by ID.
-func (s *AlarmService) DeleteAlarm(id string) (string, error) {
+// AcknowledgeAlarm records an operator acknowledgement independently of the process condition,
+// together with the acknowledging user, which defaults to the actor of ctx, and a comment.
+// Acknowledging an alarm that has already returned to normal clears it.
+func (s *AlarmService) AcknowledgeAlarm(ctx context.Context, id string, ack models.AckRequest) (models.Alarm, error) {
 	s.lock.Lock()
 	defer s.lock.Unlock()
 
-	if _, found := s.alarms[id]; found {
-		delete(s.alarms, id)
-		delete(s.notificationSchedule, id)
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+
+	next := models.ACKed
+	if alarm.Condition == models.ConditionNormal {
+		next = models.Cleared
+	}
+	if alarm.Acknowledgement == models.Acknowledged || !alarm.State.CanTransitionTo(next) {
+		return models.Alarm{}, &TransitionError{From: alarm.State, To: next}
+	}
 
-		logMessage := fmt.Sprintf("✅ Alarm ID: %s successfully deleted", id)
+	audit := auditFrom(ctx).withAck(ack)
+	now := s.clock.Now()
+	previous := alarm.State
+	alarm.State = next
+	alarm.Acknowledgement = models.Acknowledged
+	alarm.ACKedAt = now.Format(time.RFC3339)
+	alarm.ACKedBy = audit.Actor
+	alarm.AckComment = audit.Comment
+	return s.saveTransition(audit, alarm, previous, models.ReasonAcknowledged, now)
+}
+
+// UnacknowledgeAlarm reverts the acknowledgement of an alarm whose condition is still active,
+// moving it back to Triggered and restoring the unacknowledged reminder cadence. A cleared
+// alarm cannot be unacknowledged; it has to be reopened instead.
+func (s *AlarmService) UnacknowledgeAlarm(ctx context.Context, id string, ack models.AckRequest) (models.Alarm, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+	if alarm.Acknowledgement != models.Acknowledged || alarm.State == models.Cleared {
+		return models.Alarm{}, &TransitionError{From: alarm.State, To: models.Triggered}
+	}
+
+	previous := alarm.State
+	alarm.State = models.Triggered
+	alarm.Acknowledgement = models.Unacknowledged
+	alarm.ACKedAt = ""
+	alarm.ACKedBy = ""
+	alarm.AckComment = ""
+	return s.saveTransition(auditFrom(ctx).withAck(ack), alarm, previous, models.ReasonUnacknowledged, s.clock.Now())
+}
+
+// SetSuppression shelves, suppresses by design, takes out of service or, with
+// models.NotSuppressed, returns an alarm to service. Unlike ShelveAlarm, shelving an
+// alarm this way does not expire; any timed shelve is replaced.
+func (s *AlarmService) SetSuppression(ctx context.Context, id string, suppression models.SuppressionState) (models.Alarm, error) {
+	if !suppression.IsValid() {
+		return models.Alarm{}, &ValidationError{Field: "suppression", Message: "invalid suppression state"}
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+
+	alarm = withoutShelve(alarm)
+	alarm.Suppression = suppression
+	return s.saveTransition(auditFrom(ctx), alarm, alarm.State, models.ReasonSuppressionChanged, s.clock.Now())
+}
+
+// saveTransition re-derives the combined state of an updated alarm, persists it with its
+// new reminder schedule, records the change in its history and queues a notification.
+// Callers must hold the write lock.
+func (s *AlarmService) saveTransition(audit Audit, alarm models.Alarm, previous models.AlarmState, reason models.NotificationReason, now time.Time) (models.Alarm, error) {
+	alarm, ops := s.transitionOps(alarm, now)
+	if err := s.apply(append(ops, recordOp(audit, alarm, previous, reason, now))...); err != nil {
+		return models.Alarm{}, err
+	}
+	s.notify(models.Notification{Alarm: alarm, PreviousState: previous, Reason: reason})
+	return alarm, nil
+}
+
+// transitionOps re-derives the combined state, inhibition and escalation of an updated alarm and returns it
+// together with the store mutations that persist it and its new reminder schedule. An alarm moving to
+// Cleared records when it cleared; any other state forgets it.
+func (s *AlarmService) transitionOps(alarm models.Alarm, now time.Time) (models.Alarm, []store.Op) {
+	switch {
+	case alarm.State != models.Cleared:
+		alarm.ClearedAt = ""
+	case alarm.ClearedAt == "":
+		alarm.ClearedAt = now.Format(time.RFC3339)
+	}
+	alarm = s.withInhibition(alarm)
+	alarm = s.withEscalation(alarm, now)
+	alarm.ISAState = alarm.CombinedState()
+	alarm.UpdatedAt = now.Format(time.RFC3339)
+	return alarm, []store.Op{store.PutAlarm(alarm), s.scheduleOp(alarm, now)}
+}
+
+// GetAlarmTransitions returns the states and actions available to an alarm in its current state.
+func (s *AlarmService) GetAlarmTransitions(id string) (models.AlarmTransitions, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	if alarm, found := s.store.Get(id); found {
+		return alarm.State.Transitions(), nil
+	}
+	return models.AlarmTransitions{}, ErrNotFound
+}
+
+// DeleteAlarm removes an alarm and its notification schedule from the store by ID.
+// Its history is retained and records the deletion.
+func (s *AlarmService) DeleteAlarm(ctx context.Context, id string) (string, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if alarm, found := s.store.Get(id); found {
+		if err := s.apply(store.DeleteAlarm(id), deleteRecordOp(auditFrom(ctx), alarm, s.clock.Now())); err != nil {
+			return "", err
+		}
+		s.forgetDeliveries(id)
+
+		logMessage := fmt.Sprintf("Alarm ID: %s successfully deleted", id)
 		return logMessage, nil
 	}
-	errMessage := fmt.Sprintf("❌ Alarm ID: %s not found", id)
-	return "", errors.New(errMessage)
+	return "", ErrNotFound
+}
+
+// forgetDeliveries drops the delivery records of deleted alarms.
+func (s *AlarmService) forgetDeliveries(ids ...string) {
+	s.deliveryLock.Lock()
+	defer s.deliveryLock.Unlock()
+
+	for _, id := range ids {
+		delete(s.deliveries, id)
+	}
 }
 
 // validateAlarm verifies alarm data to ensure valid state and non-empty name.
 func (s *AlarmService) validateAlarm(alarm models.Alarm) error {
 	if alarm.Name == "" {
-		return errors.New("alarm name is mandatory")
+		return &ValidationError{Field: "name", Message: "alarm name is mandatory"}
 	}
 	if !alarm.State.IsValid() {
-		return errors.New("invalid alarm state")
+		return ErrInvalidState
+	}
+	if alarm.Severity != "" && !alarm.Severity.IsValid() {
+		return &ValidationError{Field: "severity", Message: "invalid alarm severity"}
+	}
+	if err := models.ValidateLabels(alarm.Labels); err != nil {
+		return &ValidationError{Field: "labels", Message: err.Error()}
+	}
+	if err := models.ValidateAnnotations(alarm.Annotations); err != nil {
+		return &ValidationError{Field: "annotations", Message: err.Error()}
+	}
+	if !alarm.Suppression.IsValid() {
+		return &ValidationError{Field: "suppression", Message: "invalid suppression state"}
 	}
 	return nil
 }
-
-// getCreatedAtTime parses the CreatedAt field as time.Time
-func (s *AlarmService) getCreatedAtTime(alarm models.Alarm) (time.Time, error) {
-	return time.Parse(time.RFC3339, alarm.CreatedAt)
-}
\ No newline at end of file
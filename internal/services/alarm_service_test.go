@@ -1,22 +1,42 @@
 package services_test
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
 	"github.com/deeprajsshetty/alarm-service/internal/services"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
 	"github.com/google/uuid"
 )
 
+// newService creates an AlarmService with opts, failing the test if the options are invalid.
+func newService(t *testing.T, opts ...services.Option) *services.AlarmService {
+	t.Helper()
+
+	svc, err := services.NewAlarmService(opts...)
+	if err != nil {
+		t.Fatalf("failed to create service: %v", err)
+	}
+	return svc
+}
+
 // TestCreateAlarm_Success verifies successful creation of an alarm with valid data.
 func TestCreateAlarm_Success(t *testing.T) {
-	svc := services.NewAlarmService()
+	svc := newService(t)
 	alarm := models.Alarm{Name: "Test Alarm", State: models.Triggered}
 
-	createdAlarm, err := svc.CreateAlarm(alarm)
+	createdAlarm, err := svc.CreateAlarm(context.Background(), alarm)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -28,16 +48,16 @@ func TestCreateAlarm_Success(t *testing.T) {
 
 // TestCreateAlarm_Validation verifies invalid scenarios for alarm creation.
 func TestCreateAlarm_Validation(t *testing.T) {
-	svc := services.NewAlarmService()
+	svc := newService(t)
 
 	// Missing Name
-	_, err := svc.CreateAlarm(models.Alarm{State: models.Triggered})
+	_, err := svc.CreateAlarm(context.Background(), models.Alarm{State: models.Triggered})
 	if err == nil || err.Error() != "alarm name is mandatory" {
 		t.Errorf("expected error 'alarm name is mandatory', got %v", err)
 	}
 
 	// Invalid State
-	_, err = svc.CreateAlarm(models.Alarm{Name: "Invalid Alarm", State: "InvalidState"})
+	_, err = svc.CreateAlarm(context.Background(), models.Alarm{Name: "Invalid Alarm", State: "InvalidState"})
 	if err == nil || err.Error() != "invalid alarm state" {
 		t.Errorf("expected error 'invalid alarm state', got %v", err)
 	}
@@ -45,9 +65,9 @@ func TestCreateAlarm_Validation(t *testing.T) {
 
 // TestGetAllAlarms verifies retrieval of all created alarms.
 func TestGetAllAlarms(t *testing.T) {
-	svc := services.NewAlarmService()
-	svc.CreateAlarm(models.Alarm{Name: "Alarm 1", State: models.Triggered})
-	svc.CreateAlarm(models.Alarm{Name: "Alarm 2", State: models.ACKed})
+	svc := newService(t)
+	svc.CreateAlarm(context.Background(), models.Alarm{Name: "Alarm 1", State: models.Triggered})
+	svc.CreateAlarm(context.Background(), models.Alarm{Name: "Alarm 2", State: models.ACKed})
 
 	alarms := svc.GetAllAlarms()
 	if len(alarms) != 2 {
@@ -57,8 +77,8 @@ func TestGetAllAlarms(t *testing.T) {
 
 // TestGetAlarmByID verifies alarm retrieval by ID, including success and failure scenarios.
 func TestGetAlarmByID(t *testing.T) {
-	svc := services.NewAlarmService()
-	alarm, _ := svc.CreateAlarm(models.Alarm{Name: "Test Alarm", State: models.Triggered})
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Test Alarm", State: models.Triggered})
 
 	// Successful Retrieval
 	retrievedAlarm, err := svc.GetAlarmByID(alarm.ID)
@@ -79,37 +99,35 @@ func TestGetAlarmByID(t *testing.T) {
 
 // TestDeleteAlarm verifies deletion success and failure scenarios.
 func TestDeleteAlarm(t *testing.T) {
-	svc := services.NewAlarmService()
-	alarm, _ := svc.CreateAlarm(models.Alarm{Name: "To Be Deleted", State: models.Active})
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "To Be Deleted", State: models.Active})
 
 	// Successful Deletion
-	msg, err := svc.DeleteAlarm(alarm.ID)
+	msg, err := svc.DeleteAlarm(context.Background(), alarm.ID)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
 
-	expectedMsg := "✅ Alarm ID: " + alarm.ID + " successfully deleted"
+	expectedMsg := "Alarm ID: " + alarm.ID + " successfully deleted"
 	if msg != expectedMsg {
 		t.Errorf("expected message %q, got %q", expectedMsg, msg)
 	}
 
 	// Non-existent ID
 	nonExistentID := uuid.New().String()
-	_, err = svc.DeleteAlarm(nonExistentID)
-	expectedError := "❌ Alarm ID: " + nonExistentID + " not found"
-
-	if err == nil || err.Error() != expectedError {
-		t.Errorf("expected error %q, got %q", expectedError, err)
+	_, err = svc.DeleteAlarm(context.Background(), nonExistentID)
+	if !errors.Is(err, services.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
 	}
 }
 
 // TestUpdateAlarmState verifies both success and failure scenarios for alarm state updates.
 func TestUpdateAlarmState(t *testing.T) {
-	svc := services.NewAlarmService()
-	alarm, _ := svc.CreateAlarm(models.Alarm{Name: "Update Test", State: models.Triggered})
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Update Test", State: models.Triggered})
 
 	// Valid State Change
-	updatedAlarm, err := svc.UpdateAlarmState(alarm.ID, models.ACKed)
+	updatedAlarm, err := svc.UpdateAlarmState(context.Background(), alarm.ID, models.ACKed)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -119,7 +137,7 @@ func TestUpdateAlarmState(t *testing.T) {
 	}
 
 	// Invalid State Change
-	_, err = svc.UpdateAlarmState(alarm.ID, "InvalidState")
+	_, err = svc.UpdateAlarmState(context.Background(), alarm.ID, "InvalidState")
 	if err == nil || err.Error() != "invalid alarm state" {
 		t.Errorf("expected error 'invalid alarm state', got %v", err)
 	}
@@ -127,11 +145,11 @@ func TestUpdateAlarmState(t *testing.T) {
 
 // TestBulkCreateAlarms verifies bulk creation scenarios, including empty lists, duplicates, and invalid states.
 func TestBulkCreateAlarms(t *testing.T) {
-	svc := services.NewAlarmService()
+	svc := newService(t)
 
 	// Successful Bulk Creation
 	alarms := []models.Alarm{{Name: "Alarm 1", State: models.Triggered}, {Name: "Alarm 2", State: models.Active}}
-	createdAlarms, err := svc.BulkCreateAlarms(alarms)
+	createdAlarms, err := svc.BulkCreateAlarms(context.Background(), alarms)
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -140,7 +158,7 @@ func TestBulkCreateAlarms(t *testing.T) {
 	}
 
 	// Empty List
-	createdAlarms, err = svc.BulkCreateAlarms([]models.Alarm{})
+	createdAlarms, err = svc.BulkCreateAlarms(context.Background(), []models.Alarm{})
 	if err != nil {
 		t.Errorf("expected no error, got %v", err)
 	}
@@ -163,8 +181,8 @@ func TestLoadSampleAlarms(t *testing.T) {
 		t.Fatalf("failed to unmarshal sample data: %v", err)
 	}
 
-	svc := services.NewAlarmService()
-	createdAlarms, err := svc.BulkCreateAlarms(sampleAlarms)
+	svc := newService(t)
+	createdAlarms, err := svc.BulkCreateAlarms(context.Background(), sampleAlarms)
 	if err != nil {
 		t.Errorf("failed to create alarms: %v", err)
 	}
@@ -172,4 +190,1553 @@ func TestLoadSampleAlarms(t *testing.T) {
 	if len(createdAlarms) != len(sampleAlarms) {
 		t.Errorf("expected %d alarms, got %d", len(sampleAlarms), len(createdAlarms))
 	}
-}
\ No newline at end of file
+}
+
+// TestFileStore_Persistence verifies alarms created through the service survive a restart.
+func TestFileStore_Persistence(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	alarm, err := newService(t, services.WithStore(st)).CreateAlarm(context.Background(), models.Alarm{Name: "Persisted", State: models.Triggered, Source: "host-1"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	reopened, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	svc := newService(t, services.WithStore(reopened))
+	if _, err := svc.GetAlarmByID(alarm.ID); err != nil {
+		t.Errorf("expected alarm %s to be reloaded, got %v", alarm.ID, err)
+	}
+	if repeat, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Persisted", State: models.Triggered, Source: "host-1"}); repeat.ID != alarm.ID {
+		t.Errorf("expected repeat after restart merged into %s, got %s", alarm.ID, repeat.ID)
+	}
+}
+
+// TestUpdateAlarmState_TransitionRules verifies the lifecycle rejects disallowed transitions.
+func TestUpdateAlarmState_TransitionRules(t *testing.T) {
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Lifecycle Test", State: models.Triggered})
+
+	if _, err := svc.UpdateAlarmState(context.Background(), alarm.ID, models.ACKed); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// ACKed alarms cannot move back to Active
+	_, err := svc.UpdateAlarmState(context.Background(), alarm.ID, models.Active)
+	var transitionErr *services.TransitionError
+	if !errors.As(err, &transitionErr) {
+		t.Fatalf("expected TransitionError, got %v", err)
+	}
+	if transitionErr.From != models.ACKed || transitionErr.To != models.Active {
+		t.Errorf("expected ACKed -> Active conflict, got %s -> %s", transitionErr.From, transitionErr.To)
+	}
+
+	if _, err := svc.UpdateAlarmState(context.Background(), alarm.ID, models.Cleared); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	// Cleared alarms can only be reopened via the dedicated action
+	if _, err := svc.UpdateAlarmState(context.Background(), alarm.ID, models.Triggered); !errors.As(err, &transitionErr) {
+		t.Errorf("expected TransitionError, got %v", err)
+	}
+}
+
+// TestReopenAlarm verifies a Cleared alarm is reopened to Triggered and other states are rejected.
+func TestReopenAlarm(t *testing.T) {
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Reopen Test", State: models.Triggered})
+
+	var transitionErr *services.TransitionError
+	if _, err := svc.ReopenAlarm(context.Background(), alarm.ID); !errors.As(err, &transitionErr) {
+		t.Errorf("expected TransitionError reopening a Triggered alarm, got %v", err)
+	}
+
+	svc.UpdateAlarmState(context.Background(), alarm.ID, models.Cleared)
+	reopened, err := svc.ReopenAlarm(context.Background(), alarm.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if reopened.State != models.Triggered {
+		t.Errorf("expected state Triggered, got %v", reopened.State)
+	}
+
+	transitions, err := svc.GetAlarmTransitions(alarm.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(transitions.States) != 3 {
+		t.Errorf("expected 3 allowed states from Triggered, got %v", transitions.States)
+	}
+}
+
+// TestConditionAndAcknowledgement verifies condition and acknowledgement are tracked independently.
+func TestConditionAndAcknowledgement(t *testing.T) {
+	st := store.NewMemoryStore()
+	svc := newService(t, services.WithStore(st))
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Tank Level High", State: models.Triggered})
+
+	if alarm.ISAState != models.ISAUnacknowledged {
+		t.Errorf("expected new alarm to be UNACK, got %v", alarm.ISAState)
+	}
+
+	// Source reports return to normal before the operator acknowledges
+	alarm, err := svc.ReportCondition(context.Background(), alarm.ID, models.ConditionNormal)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.ISAState != models.ISAReturnedUnacked || alarm.State != models.Triggered {
+		t.Errorf("expected RTNUN while still Triggered, got %v / %v", alarm.ISAState, alarm.State)
+	}
+
+	// Acknowledging a returned-to-normal alarm clears it and stops reminders
+	alarm, err = svc.AcknowledgeAlarm(context.Background(), alarm.ID, models.AckRequest{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.ISAState != models.ISANormal || alarm.State != models.Cleared {
+		t.Errorf("expected NORM and Cleared, got %v / %v", alarm.ISAState, alarm.State)
+	}
+	if _, scheduled := st.NextNotification(alarm.ID); scheduled {
+		t.Errorf("expected no reminder scheduled for a normal alarm")
+	}
+
+	// Condition becoming active again reopens the alarm
+	alarm, err = svc.ReportCondition(context.Background(), alarm.ID, models.ConditionActive)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.ISAState != models.ISAUnacknowledged || alarm.State != models.Triggered {
+		t.Errorf("expected UNACK and Triggered after reopen, got %v / %v", alarm.ISAState, alarm.State)
+	}
+	if _, scheduled := st.NextNotification(alarm.ID); !scheduled {
+		t.Errorf("expected reminder scheduled for an unacknowledged alarm")
+	}
+
+	// Acknowledging twice is a conflict
+	svc.AcknowledgeAlarm(context.Background(), alarm.ID, models.AckRequest{})
+	var transitionErr *services.TransitionError
+	if _, err := svc.AcknowledgeAlarm(context.Background(), alarm.ID, models.AckRequest{}); !errors.As(err, &transitionErr) {
+		t.Errorf("expected TransitionError, got %v", err)
+	}
+}
+
+// TestSetSuppression verifies suppression overrides the combined state and pauses reminders.
+func TestSetSuppression(t *testing.T) {
+	st := store.NewMemoryStore()
+	svc := newService(t, services.WithStore(st))
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Pump Trip", State: models.Triggered})
+
+	alarm, err := svc.SetSuppression(context.Background(), alarm.ID, models.OutOfService)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.ISAState != models.ISAOutOfService {
+		t.Errorf("expected OOSRV, got %v", alarm.ISAState)
+	}
+	if _, scheduled := st.NextNotification(alarm.ID); scheduled {
+		t.Errorf("expected no reminder while out of service")
+	}
+
+	alarm, _ = svc.SetSuppression(context.Background(), alarm.ID, models.NotSuppressed)
+	if alarm.ISAState != models.ISAUnacknowledged {
+		t.Errorf("expected UNACK after returning to service, got %v", alarm.ISAState)
+	}
+
+	if _, err := svc.SetSuppression(context.Background(), alarm.ID, "Bogus"); err == nil {
+		t.Errorf("expected error for invalid suppression state")
+	}
+}
+
+// TestSeverity verifies severity defaulting, validation and per-severity reminder cadence.
+func TestSeverity(t *testing.T) {
+	st := store.NewMemoryStore()
+	svc := newService(t, services.WithStore(st))
+
+	minor, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered})
+	if minor.Severity != models.Minor {
+		t.Errorf("expected default severity Minor, got %v", minor.Severity)
+	}
+
+	critical, err := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Datacenter Outage", State: models.Triggered, Severity: models.Critical})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	criticalNext, _ := st.NextNotification(critical.ID)
+	minorNext, _ := st.NextNotification(minor.ID)
+	if until := time.Until(criticalNext); until > 5*time.Minute || until <= 0 {
+		t.Errorf("expected Critical reminder within 5 minutes, got %v", until)
+	}
+	if !minorNext.After(criticalNext.Add(time.Hour)) {
+		t.Errorf("expected Minor reminder to be later than Critical, got %v vs %v", minorNext, criticalNext)
+	}
+
+	_, err = svc.CreateAlarm(context.Background(), models.Alarm{Name: "Bad Severity", State: models.Triggered, Severity: "Catastrophic"})
+	if err == nil || err.Error() != "invalid alarm severity" {
+		t.Errorf("expected error 'invalid alarm severity', got %v", err)
+	}
+
+	alarms := svc.GetAlarmsBySeverity(models.Critical)
+	if len(alarms) != 1 || alarms[0].ID != critical.ID {
+		t.Errorf("expected only the Critical alarm, got %+v", alarms)
+	}
+}
+
+// recordingNotifier is a Notifier that records notifications and optionally fails.
+type recordingNotifier struct {
+	name string
+	err  error
+
+	lock          sync.Mutex
+	notifications []models.Notification
+}
+
+func (n *recordingNotifier) Name() string { return n.name }
+
+func (n *recordingNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	n.notifications = append(n.notifications, notification)
+	return n.err
+}
+
+func (n *recordingNotifier) received() []models.Notification {
+	n.lock.Lock()
+	defer n.lock.Unlock()
+
+	return append([]models.Notification{}, n.notifications...)
+}
+
+// waitFor polls cond until it returns true or fails the test after a timeout.
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("condition not met before timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+// TestNotifiers_FanOut verifies notifications reach every notifier and failures are recorded per notifier.
+func TestNotifiers_FanOut(t *testing.T) {
+	healthy := &recordingNotifier{name: "healthy"}
+	failing := &recordingNotifier{name: "failing", err: errors.New("pager unreachable")}
+	registry, _ := notify.NewRegistry(failing, healthy)
+	svc := newService(t, services.WithNotifiers(registry))
+
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Datacenter Outage", State: models.Triggered, Severity: models.Critical})
+	svc.UpdateAlarmState(context.Background(), alarm.ID, models.ACKed)
+
+	waitFor(t, func() bool {
+		status, _ := svc.GetDeliveryStatus(alarm.ID)
+		return len(status.Results) == 4
+	})
+
+	notifications := healthy.received()
+	if notifications[0].Reason != models.ReasonCreated || notifications[0].Count != 1 {
+		t.Errorf("expected first notification for creation with count 1, got %+v", notifications[0])
+	}
+	if notifications[1].PreviousState != models.Triggered || notifications[1].Count != 2 {
+		t.Errorf("expected second notification from Triggered with count 2, got %+v", notifications[1])
+	}
+
+	status, err := svc.GetDeliveryStatus(alarm.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if status.NotificationCount != 2 {
+		t.Fatalf("expected 2 notifications, got %+v", status)
+	}
+	for _, result := range status.Results {
+		if result.Notifier == "failing" && (result.Success || result.Error != "pager unreachable") {
+			t.Errorf("expected failing notifier to record its error, got %+v", result)
+		}
+		if result.Notifier == "healthy" && !result.Success {
+			t.Errorf("expected healthy notifier to succeed, got %+v", result)
+		}
+	}
+}
+
+// TestNotifiers_DeliveredInOrder verifies every notifier receives notifications in the order they were raised.
+func TestNotifiers_DeliveredInOrder(t *testing.T) {
+	first := &recordingNotifier{name: "first"}
+	second := &recordingNotifier{name: "second"}
+	registry, _ := notify.NewRegistry(first, second)
+	svc := newService(t, services.WithNotifiers(registry))
+
+	const count = 200
+	for i := 0; i < count; i++ {
+		svc.CreateAlarm(context.Background(), models.Alarm{Name: fmt.Sprintf("Alarm %d", i), State: models.Triggered})
+	}
+
+	for _, notifier := range []*recordingNotifier{first, second} {
+		waitFor(t, func() bool { return len(notifier.received()) == count })
+		for i, notification := range notifier.received() {
+			if want := fmt.Sprintf("Alarm %d", i); notification.Alarm.Name != want {
+				t.Fatalf("expected %s to receive %q at position %d, got %q", notifier.name, want, i, notification.Alarm.Name)
+			}
+		}
+	}
+}
+
+// blockingNotifier is a Notifier that blocks until released.
+type blockingNotifier struct {
+	release chan struct{}
+}
+
+func (n *blockingNotifier) Name() string { return "blocking" }
+
+func (n *blockingNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	<-n.release
+	return nil
+}
+
+// TestNotifiers_SlowNotifierDoesNotBlock verifies a stalled notifier does not delay other deliveries.
+func TestNotifiers_SlowNotifierDoesNotBlock(t *testing.T) {
+	slow := &blockingNotifier{release: make(chan struct{})}
+	defer close(slow.release)
+	fast := &recordingNotifier{name: "fast"}
+	registry, _ := notify.NewRegistry(slow, fast)
+	svc := newService(t, services.WithNotifiers(registry))
+
+	for i := 0; i < 3; i++ {
+		svc.CreateAlarm(context.Background(), models.Alarm{Name: fmt.Sprintf("Alarm %d", i), State: models.Triggered})
+	}
+
+	waitFor(t, func() bool { return len(fast.received()) == 3 })
+}
+
+// fakeClock is a manually advanced services.Clock.
+type fakeClock struct {
+	lock   sync.Mutex
+	now    time.Time
+	timers []*fakeTimer
+}
+
+// fakeTimer is a services.Timer fired by fakeClock.Advance.
+type fakeTimer struct {
+	clock *fakeClock
+	at    time.Time
+	ch    chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 1, 1, 10, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	return c.now
+}
+
+func (c *fakeClock) NewTimer(d time.Duration) services.Timer {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	timer := &fakeTimer{clock: c, at: c.now.Add(d), ch: make(chan time.Time, 1)}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+// Advance moves the clock forward by d and fires every timer that became due.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	c.now = c.now.Add(d)
+	pending := c.timers[:0]
+	for _, timer := range c.timers {
+		if timer.at.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- c.now
+	}
+	c.timers = pending
+}
+
+// timerPending reports whether a timer due at exactly at is waiting to fire.
+func (c *fakeClock) timerPending(at time.Time) bool {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	for _, timer := range c.timers {
+		if timer.at.Equal(at) {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *fakeTimer) C() <-chan time.Time { return t.ch }
+
+func (t *fakeTimer) Stop() bool {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+
+	for i, timer := range t.clock.timers {
+		if timer == t {
+			t.clock.timers = append(t.clock.timers[:i], t.clock.timers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// TestScheduler_ReminderFiresWhenDue verifies a reminder fires exactly at its due time and is rescheduled.
+func TestScheduler_ReminderFiresWhenDue(t *testing.T) {
+	clock := newFakeClock()
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry))
+
+	start := clock.Now()
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Datacenter Outage", State: models.Triggered, Severity: models.Critical})
+
+	schedule, err := svc.GetNextNotification(alarm.ID)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if schedule.NextNotificationAt != start.Add(5*time.Minute).Format(time.RFC3339) {
+		t.Fatalf("expected reminder due in 5 minutes, got %+v", schedule)
+	}
+
+	waitFor(t, func() bool { return clock.timerPending(start.Add(5 * time.Minute)) })
+	clock.Advance(5*time.Minute - time.Second)
+	clock.Advance(time.Second)
+
+	waitFor(t, func() bool { return len(recorder.received()) == 2 })
+	if reason := recorder.received()[1].Reason; reason != models.ReasonReminder {
+		t.Errorf("expected reminder notification, got %s", reason)
+	}
+
+	schedule, _ = svc.GetNextNotification(alarm.ID)
+	if schedule.NextNotificationAt != start.Add(10*time.Minute).Format(time.RFC3339) {
+		t.Errorf("expected reminder rescheduled 5 minutes later, got %+v", schedule)
+	}
+}
+
+// TestScheduler_CancelOnStateChangeAndDelete verifies reminders are cancelled when no longer due.
+func TestScheduler_CancelOnStateChangeAndDelete(t *testing.T) {
+	clock := newFakeClock()
+	svc := newService(t, services.WithClock(clock))
+	start := clock.Now()
+
+	cleared, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Full", State: models.Triggered, Severity: models.Critical})
+	clock.Advance(time.Minute)
+	svc.AcknowledgeAlarm(context.Background(), cleared.ID, models.AckRequest{})
+
+	schedule, _ := svc.GetNextNotification(cleared.ID)
+	if schedule.NextNotificationAt != start.Add(time.Hour+time.Minute).Format(time.RFC3339) {
+		t.Errorf("expected acknowledged reminder in 1 hour, got %+v", schedule)
+	}
+
+	svc.UpdateAlarmState(context.Background(), cleared.ID, models.Cleared)
+	if schedule, _ := svc.GetNextNotification(cleared.ID); schedule.NextNotificationAt != "" {
+		t.Errorf("expected no reminder for a cleared alarm, got %+v", schedule)
+	}
+
+	deleted, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Fan Failure", State: models.Triggered})
+	svc.DeleteAlarm(context.Background(), deleted.ID)
+	if _, err := svc.GetNextNotification(deleted.ID); err == nil {
+		t.Errorf("expected error for a deleted alarm")
+	}
+}
+
+// TestBulkCreateAlarms_ThousandsDoNotDeadlock is a regression test for bulk creations
+// exceeding the notification queue capacity blocking the service lock.
+func TestBulkCreateAlarms_ThousandsDoNotDeadlock(t *testing.T) {
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithNotifiers(registry))
+
+	alarms := make([]models.Alarm, 5000)
+	for i := range alarms {
+		alarms[i] = models.Alarm{Name: fmt.Sprintf("Alarm %d", i), State: models.Triggered}
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		svc.BulkCreateAlarms(context.Background(), alarms)
+		svc.BulkCreateAlarms(context.Background(), alarms)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("bulk creation blocked")
+	}
+
+	waitFor(t, func() bool { return len(recorder.received()) == 10000 })
+	stats := svc.NotificationStats()
+	if stats.Outbox.Enqueued != 10000 || stats.Outbox.Dropped != 0 {
+		t.Errorf("expected 10000 notifications queued without drops, got %+v", stats.Outbox)
+	}
+}
+
+// TestNotificationStats_ReportsBackpressure verifies a stalled notifier fills only its own
+// bounded queue and that dropped notifications are reported.
+func TestNotificationStats_ReportsBackpressure(t *testing.T) {
+	slow := &blockingNotifier{release: make(chan struct{})}
+	defer close(slow.release)
+	fast := &recordingNotifier{name: "fast"}
+	registry, _ := notify.NewRegistry(slow, fast)
+	svc := newService(t, services.WithNotifiers(registry), services.WithNotificationQueueSize(10))
+
+	for i := 0; i < 5; i++ {
+		alarms := make([]models.Alarm, 10)
+		for j := range alarms {
+			alarms[j] = models.Alarm{Name: fmt.Sprintf("Alarm %d-%d", i, j), State: models.Triggered}
+		}
+		svc.BulkCreateAlarms(context.Background(), alarms)
+		waitFor(t, func() bool { return svc.NotificationStats().Outbox.Pending == 0 })
+	}
+
+	stats := svc.NotificationStats()
+	if len(stats.Notifiers) != 2 || stats.Notifiers[0].Name != "blocking" || stats.Notifiers[1].Name != "fast" {
+		t.Fatalf("expected stats for both notifiers, got %+v", stats.Notifiers)
+	}
+	if blocking := stats.Notifiers[0]; blocking.Pending != 10 || blocking.Dropped == 0 {
+		t.Errorf("expected the stalled notifier queue to be full and dropping, got %+v", blocking)
+	}
+	waitFor(t, func() bool { return len(fast.received()) == 50 })
+}
+
+// TestClose_DrainsNotificationsAndFlushesStore verifies Close delivers queued notifications,
+// persists the store and rejects further mutations.
+func TestClose_DrainsNotificationsAndFlushesStore(t *testing.T) {
+	dir := t.TempDir()
+	st, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithStore(st), services.WithNotifiers(registry))
+
+	alarms := make([]models.Alarm, 500)
+	for i := range alarms {
+		alarms[i] = models.Alarm{Name: fmt.Sprintf("Alarm %d", i), State: models.Triggered}
+	}
+	created, _ := svc.BulkCreateAlarms(context.Background(), alarms)
+
+	if err := svc.Close(context.Background()); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if received := len(recorder.received()); received != len(alarms) {
+		t.Errorf("expected %d notifications delivered before Close returned, got %d", len(alarms), received)
+	}
+	if _, err := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Late", State: models.Triggered}); !errors.Is(err, services.ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+	if err := svc.Close(context.Background()); err != nil {
+		t.Errorf("expected closing twice to be a no-op, got %v", err)
+	}
+
+	if _, err := os.Stat(filepath.Join(dir, "alarms.json")); err != nil {
+		t.Fatalf("expected a snapshot to be written on close, got %v", err)
+	}
+	reopened, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+	if _, found := reopened.Get(created[0].ID); !found {
+		t.Errorf("expected alarm %s to be persisted", created[0].ID)
+	}
+}
+
+// contextNotifier is a Notifier that blocks until its context is cancelled.
+type contextNotifier struct {
+	cancelled chan struct{}
+}
+
+func (n *contextNotifier) Name() string { return "context" }
+
+func (n *contextNotifier) Notify(ctx context.Context, notification models.Notification) error {
+	<-ctx.Done()
+	close(n.cancelled)
+	return ctx.Err()
+}
+
+// TestClose_DeadlineCancelsDeliveries verifies Close gives up on deliveries outliving its context.
+func TestClose_DeadlineCancelsDeliveries(t *testing.T) {
+	stuck := &contextNotifier{cancelled: make(chan struct{})}
+	registry, _ := notify.NewRegistry(stuck)
+	svc := newService(t, services.WithNotifiers(registry))
+	svc.CreateAlarm(context.Background(), models.Alarm{Name: "Stuck", State: models.Triggered})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := svc.Close(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected deadline exceeded, got %v", err)
+	}
+
+	select {
+	case <-stuck.cancelled:
+	case <-time.After(2 * time.Second):
+		t.Errorf("expected the in-flight delivery to be cancelled")
+	}
+}
+
+// TestRun_ClosesWhenContextCancelled verifies Run shuts the service down once its context ends.
+func TestRun_ClosesWhenContextCancelled(t *testing.T) {
+	svc := newService(t)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	done := make(chan error, 1)
+	go func() { done <- svc.Run(ctx) }()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatalf("expected Run to return after cancellation")
+	}
+	if _, err := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Late", State: models.Triggered}); !errors.Is(err, services.ErrClosed) {
+		t.Errorf("expected ErrClosed, got %v", err)
+	}
+}
+
+// TestClose_NoGoroutineLeak verifies services can be created and disposed repeatedly
+// without leaking their scheduler and notification workers.
+func TestClose_NoGoroutineLeak(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 50; i++ {
+		recorder := &recordingNotifier{name: "recorder"}
+		registry, _ := notify.NewRegistry(recorder)
+		svc := newService(t, services.WithNotifiers(registry))
+		svc.CreateAlarm(context.Background(), models.Alarm{Name: fmt.Sprintf("Alarm %d", i), State: models.Triggered, Severity: models.Critical})
+		if err := svc.Close(context.Background()); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+	}
+
+	waitFor(t, func() bool { return runtime.NumGoroutine() <= before })
+}
+
+// TestListAlarms_FilterSortAndPaginate verifies filtering, ordering and cursor pagination.
+func TestListAlarms_FilterSortAndPaginate(t *testing.T) {
+	clock := newFakeClock()
+	svc := newService(t, services.WithClock(clock))
+
+	var created []models.Alarm
+	for i := 0; i < 7; i++ {
+		alarm := models.Alarm{Name: fmt.Sprintf("Pump %d", i), State: models.Triggered, Labels: map[string]string{"site": "north"}}
+		if i%2 == 1 {
+			alarm.Name = fmt.Sprintf("Fan %d", i)
+			alarm.Labels["site"] = "south"
+		}
+		a, _ := svc.CreateAlarm(context.Background(), alarm)
+		created = append(created, a)
+		clock.Advance(time.Minute)
+	}
+
+	query := models.AlarmQuery{Labels: map[string]string{"site": "north"}, Descending: true, Limit: 3}
+	var names []string
+	for pages := 0; ; pages++ {
+		page, err := svc.ListAlarms(context.Background(), query)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for _, alarm := range page.Alarms {
+			names = append(names, alarm.Name)
+		}
+		if page.NextCursor == "" {
+			if pages != 1 {
+				t.Errorf("expected 2 pages, got %d", pages+1)
+			}
+			break
+		}
+		query.Cursor = page.NextCursor
+	}
+	if fmt.Sprint(names) != "[Pump 6 Pump 4 Pump 2 Pump 0]" {
+		t.Errorf("expected north alarms newest first, got %v", names)
+	}
+
+	page, _ := svc.ListAlarms(context.Background(), models.AlarmQuery{
+		NameContains: "fan",
+		CreatedAfter: clock.Now().Add(-5 * time.Minute),
+	})
+	if len(page.Alarms) != 2 || page.Alarms[0].ID != created[3].ID || page.Alarms[1].ID != created[5].ID {
+		t.Errorf("expected Fan 3 and Fan 5, got %+v", page.Alarms)
+	}
+
+	query.Descending = false
+	if _, err := svc.ListAlarms(context.Background(), query); !errors.Is(err, services.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor for a cursor issued for another ordering, got %v", err)
+	}
+	if _, err := svc.ListAlarms(context.Background(), models.AlarmQuery{SortBy: "name"}); !errors.Is(err, services.ErrInvalidQuery) {
+		t.Errorf("expected ErrInvalidQuery for an unknown sort field, got %v", err)
+	}
+}
+
+// TestListAlarms_DefaultLimit verifies listings without a limit are paged at the default page size.
+func TestListAlarms_DefaultLimit(t *testing.T) {
+	registry, _ := notify.NewRegistry(&recordingNotifier{name: "recorder"})
+	svc := newService(t, services.WithNotifiers(registry))
+	for i := 0; i < 150; i++ {
+		svc.CreateAlarm(context.Background(), models.Alarm{Name: fmt.Sprintf("Alarm %d", i), State: models.Triggered})
+	}
+
+	page, err := svc.ListAlarms(context.Background(), models.AlarmQuery{})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(page.Alarms) != 100 || page.NextCursor == "" {
+		t.Fatalf("expected a first page of 100 alarms with a cursor, got %d alarms (cursor=%q)", len(page.Alarms), page.NextCursor)
+	}
+	page, _ = svc.ListAlarms(context.Background(), models.AlarmQuery{Cursor: page.NextCursor})
+	if len(page.Alarms) != 50 || page.NextCursor != "" {
+		t.Errorf("expected a last page of 50 alarms without a cursor, got %d alarms (cursor=%q)", len(page.Alarms), page.NextCursor)
+	}
+	if alarms := svc.GetAlarmsBySeverity(models.Minor); len(alarms) != 150 {
+		t.Errorf("expected every alarm of a severity regardless of the page size, got %d", len(alarms))
+	}
+}
+
+// TestTypedErrors verifies service errors can be classified with errors.Is and errors.As.
+func TestTypedErrors(t *testing.T) {
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Typed", State: models.Triggered})
+
+	if _, err := svc.UpdateAlarmState(context.Background(), alarm.ID, "Exploded"); !errors.Is(err, services.ErrInvalidState) || !errors.Is(err, services.ErrValidation) {
+		t.Errorf("expected ErrInvalidState wrapping ErrValidation, got %v", err)
+	}
+	if _, err := svc.UpdateAlarmState(context.Background(), uuid.New().String(), models.ACKed); !errors.Is(err, services.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	svc.UpdateAlarmState(context.Background(), alarm.ID, models.Cleared)
+	if _, err := svc.UpdateAlarmState(context.Background(), alarm.ID, models.ACKed); !errors.Is(err, services.ErrConflict) {
+		t.Errorf("expected ErrConflict, got %v", err)
+	}
+
+	var validationErr *services.ValidationError
+	if _, err := svc.CreateAlarm(context.Background(), models.Alarm{State: models.Triggered}); !errors.As(err, &validationErr) || validationErr.Field != "name" {
+		t.Errorf("expected a ValidationError for name, got %v", err)
+	}
+
+	created, err := svc.BulkCreateAlarms(context.Background(), []models.Alarm{{Name: "Ok", State: models.Triggered}, {Name: "Bad", State: "Exploded"}})
+	var bulkErr *services.BulkError
+	if len(created) != 1 || !errors.As(err, &bulkErr) || len(bulkErr.Items) != 1 || bulkErr.Items[0].Index != 1 {
+		t.Fatalf("expected one created alarm and a BulkError for item 1, got %v, %v", created, err)
+	}
+	if !errors.Is(bulkErr.Items[0].Err, services.ErrInvalidState) {
+		t.Errorf("expected item 1 to fail with ErrInvalidState, got %v", bulkErr.Items[0].Err)
+	}
+}
+
+// TestBulkOperations_Atomic verifies an atomic bulk operation with a failing item changes nothing.
+func TestBulkOperations_Atomic(t *testing.T) {
+	svc := newService(t)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Atomic", State: models.Triggered})
+
+	results, err := svc.UpdateAlarmStates(context.Background(), []models.StateUpdate{
+		{ID: alarm.ID, State: models.ACKed},
+		{ID: uuid.New().String(), State: models.ACKed},
+	}, services.BulkOptions{Atomic: true})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !errors.Is(results[0].Err, services.ErrRolledBack) || !errors.Is(results[1].Err, services.ErrNotFound) {
+		t.Errorf("expected a rolled back and a not found item, got %+v", results)
+	}
+	if current, _ := svc.GetAlarmByID(alarm.ID); current.State != models.Triggered {
+		t.Errorf("expected alarm to stay Triggered, got %s", current.State)
+	}
+
+	results, _ = svc.DeleteAlarms(context.Background(), []string{alarm.ID, alarm.ID}, services.BulkOptions{Atomic: true})
+	if !errors.Is(results[1].Err, services.ErrNotFound) {
+		t.Errorf("expected deleting the same alarm twice to fail, got %+v", results[1])
+	}
+	if _, err := svc.GetAlarmByID(alarm.ID); err != nil {
+		t.Errorf("expected alarm to survive the rolled back deletion, got %v", err)
+	}
+}
+
+// TestCreateAlarm_Deduplication verifies repeats of an alarm are merged into its latest instance.
+func TestCreateAlarm_Deduplication(t *testing.T) {
+	clock := newFakeClock()
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry), services.WithReopenWindow(10*time.Minute))
+
+	disk := models.Alarm{Name: "Disk Space Alert", State: models.Triggered, Severity: models.Minor, Source: "host-1", Labels: map[string]string{"mount": "/var"}}
+	first, _ := svc.CreateAlarm(context.Background(), disk)
+	if first.DedupKey == "" || first.Occurrences != 1 {
+		t.Fatalf("expected derived dedup key and one occurrence, got %+v", first)
+	}
+
+	clock.Advance(time.Minute)
+	repeat, err := svc.CreateAlarm(context.Background(), disk)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if repeat.ID != first.ID || repeat.Occurrences != 2 || repeat.LastSeen != clock.Now().Format(time.RFC3339) {
+		t.Errorf("expected repeat merged into %s, got %+v", first.ID, repeat)
+	}
+
+	disk.Severity = models.Critical
+	escalated, _ := svc.CreateAlarm(context.Background(), disk)
+	if escalated.ID != first.ID || escalated.Severity != models.Critical || escalated.Occurrences != 3 {
+		t.Errorf("expected severity escalated on %s, got %+v", first.ID, escalated)
+	}
+
+	other, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Disk Space Alert", State: models.Triggered, Source: "host-2"})
+	if other.ID == first.ID {
+		t.Error("expected an alarm from another source to be a new alarm")
+	}
+	if len(svc.GetAllAlarms()) != 2 {
+		t.Errorf("expected 2 alarms, got %d", len(svc.GetAllAlarms()))
+	}
+
+	svc.UpdateAlarmState(context.Background(), first.ID, models.Cleared)
+	clock.Advance(5 * time.Minute)
+	reopened, _ := svc.CreateAlarm(context.Background(), disk)
+	if reopened.ID != first.ID || reopened.State != models.Triggered || reopened.Occurrences != 4 {
+		t.Errorf("expected cleared alarm reopened within the window, got %+v", reopened)
+	}
+
+	svc.UpdateAlarmState(context.Background(), first.ID, models.Cleared)
+	clock.Advance(11 * time.Minute)
+	instance, _ := svc.CreateAlarm(context.Background(), disk)
+	if instance.ID == first.ID || instance.Occurrences != 1 {
+		t.Errorf("expected a new instance after the reopen window, got %+v", instance)
+	}
+
+	svc.Close(context.Background())
+	var reasons []models.NotificationReason
+	for _, notification := range recorder.received() {
+		if notification.Alarm.ID == first.ID {
+			reasons = append(reasons, notification.Reason)
+		}
+	}
+	expected := []models.NotificationReason{models.ReasonCreated, models.ReasonSeverityEscalated, models.ReasonReopened}
+	if !slices.Equal(reasons, expected) {
+		t.Errorf("expected notifications %v, got %v", expected, reasons)
+	}
+}
+
+// TestCreateAlarm_ReopenWindowFromClear verifies the reopen window runs from when the alarm
+// was cleared, not from its last update.
+func TestCreateAlarm_ReopenWindowFromClear(t *testing.T) {
+	clock := newFakeClock()
+	svc := newService(t, services.WithClock(clock), services.WithReopenWindow(10*time.Minute))
+
+	disk := models.Alarm{Name: "Disk Space Alert", State: models.Triggered, Source: "host-1"}
+	first, _ := svc.CreateAlarm(context.Background(), disk)
+	cleared, _ := svc.UpdateAlarmState(context.Background(), first.ID, models.Cleared)
+	if cleared.ClearedAt != clock.Now().Format(time.RFC3339) {
+		t.Errorf("expected cleared_at %s, got %q", clock.Now().Format(time.RFC3339), cleared.ClearedAt)
+	}
+
+	clock.Advance(8 * time.Minute)
+	if _, err := svc.SetSuppression(context.Background(), first.ID, models.OutOfService); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	clock.Advance(3 * time.Minute)
+	instance, _ := svc.CreateAlarm(context.Background(), disk)
+	if instance.ID == first.ID {
+		t.Errorf("expected a new instance 11 minutes after clearing, got %+v", instance)
+	}
+
+	reopened, _ := svc.ReopenAlarm(context.Background(), first.ID)
+	if reopened.ClearedAt != "" {
+		t.Errorf("expected cleared_at to be reset on reopen, got %q", reopened.ClearedAt)
+	}
+}
+
+// TestCreateAlarms_DeduplicatesWithinBatch verifies explicit dedup keys merge repeats in one request.
+func TestCreateAlarms_DeduplicatesWithinBatch(t *testing.T) {
+	svc := newService(t)
+
+	results, _ := svc.CreateAlarms(context.Background(), []models.Alarm{
+		{Name: "Link Down", State: models.Triggered, DedupKey: "switch-7/port-3"},
+		{Name: "Link Down Again", State: models.Triggered, DedupKey: "switch-7/port-3"},
+		{Name: "Link Down", State: models.Triggered},
+		{Name: "Link Down", State: models.Triggered},
+	}, services.BulkOptions{})
+
+	if results[1].Alarm.ID != results[0].Alarm.ID || results[1].Alarm.Occurrences != 2 {
+		t.Errorf("expected second item merged into the first, got %+v", results[1].Alarm)
+	}
+	if results[2].Alarm.ID == results[3].Alarm.ID {
+		t.Error("expected alarms without dedup key or source never to be merged")
+	}
+	if len(svc.GetAllAlarms()) != 3 {
+		t.Errorf("expected 3 alarms, got %d", len(svc.GetAllAlarms()))
+	}
+}
+
+// TestGetAlarmHistory verifies every transition is recorded with its audit details and
+// that response times are derived from the history.
+func TestGetAlarmHistory(t *testing.T) {
+	clock := newFakeClock()
+	svc := newService(t, services.WithClock(clock))
+	ctx := services.WithAudit(context.Background(), services.Audit{Actor: "operator-1", Via: "console"})
+
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Pump Failure", State: models.Triggered})
+	clock.Advance(2 * time.Minute)
+	svc.AcknowledgeAlarm(ctx, alarm.ID, models.AckRequest{})
+	clock.Advance(3 * time.Minute)
+	svc.ReportCondition(context.Background(), alarm.ID, models.ConditionNormal)
+	svc.DeleteAlarm(ctx, alarm.ID)
+
+	history, err := svc.GetAlarmHistory(alarm.ID)
+	if err != nil {
+		t.Fatalf("expected history to be retained after deletion, got %v", err)
+	}
+	if !history.Deleted || len(history.Transitions) != 4 {
+		t.Fatalf("expected 4 transitions of a deleted alarm, got %+v", history)
+	}
+
+	ack := history.Transitions[1]
+	if ack.From != models.Triggered || ack.To != models.ACKed || ack.Reason != models.ReasonAcknowledged || ack.Actor != "operator-1" || ack.Via != "console" {
+		t.Errorf("expected acknowledgement by operator-1, got %+v", ack)
+	}
+	if cleared := history.Transitions[2]; cleared.To != models.Cleared || cleared.Via != "service" {
+		t.Errorf("expected clear through the service, got %+v", cleared)
+	}
+	if history.TimeToAcknowledgeSeconds == nil || *history.TimeToAcknowledgeSeconds != 120 {
+		t.Errorf("expected time to acknowledge of 120s, got %v", history.TimeToAcknowledgeSeconds)
+	}
+	if history.TimeToClearSeconds == nil || *history.TimeToClearSeconds != 300 {
+		t.Errorf("expected time to clear of 300s, got %v", history.TimeToClearSeconds)
+	}
+
+	if _, err := svc.GetAlarmHistory("unknown"); !errors.Is(err, services.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+}
+
+// TestAcknowledgeAndUnacknowledge verifies acknowledgements record the user and comment and
+// that reverting one restores the unacknowledged reminder cadence.
+func TestAcknowledgeAndUnacknowledge(t *testing.T) {
+	clock := newFakeClock()
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry))
+	start := clock.Now()
+
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Compressor Trip", State: models.Triggered, Severity: models.Critical})
+	ctx := services.WithAudit(context.Background(), services.Audit{Actor: "operator-1"})
+	alarm, err := svc.AcknowledgeAlarm(ctx, alarm.ID, models.AckRequest{Comment: "crew dispatched"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.ACKedBy != "operator-1" || alarm.AckComment != "crew dispatched" {
+		t.Errorf("expected acknowledgement by the request actor with comment, got %+v", alarm)
+	}
+
+	alarm, err = svc.UnacknowledgeAlarm(ctx, alarm.ID, models.AckRequest{User: "supervisor", Comment: "acknowledged by mistake"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.State != models.Triggered || alarm.ISAState != models.ISAUnacknowledged || alarm.ACKedBy != "" || alarm.ACKedAt != "" {
+		t.Errorf("expected alarm back to Triggered and UNACK, got %+v", alarm)
+	}
+	schedule, _ := svc.GetNextNotification(alarm.ID)
+	if schedule.NextNotificationAt != start.Add(5*time.Minute).Format(time.RFC3339) {
+		t.Errorf("expected critical unacknowledged reminder in 5 minutes, got %+v", schedule)
+	}
+
+	var transitionErr *services.TransitionError
+	if _, err := svc.UnacknowledgeAlarm(ctx, alarm.ID, models.AckRequest{}); !errors.As(err, &transitionErr) {
+		t.Errorf("expected TransitionError for an unacknowledged alarm, got %v", err)
+	}
+
+	history, _ := svc.GetAlarmHistory(alarm.ID)
+	if last := history.Transitions[len(history.Transitions)-1]; last.Actor != "supervisor" || last.Comment != "acknowledged by mistake" || last.Reason != models.ReasonUnacknowledged {
+		t.Errorf("expected unacknowledgement recorded with user and comment, got %+v", last)
+	}
+
+	svc.Close(context.Background())
+	notifications := recorder.received()
+	if len(notifications) != 3 || notifications[1].Alarm.ACKedBy != "operator-1" || notifications[2].Reason != models.ReasonUnacknowledged {
+		t.Errorf("expected acknowledgement and unacknowledgement notifications, got %+v", notifications)
+	}
+}
+
+// TestUpdateAlarmState_ClearAcknowledges verifies clearing an unacknowledged alarm records the
+// acknowledgement against the actor, while an earlier acknowledgement is kept.
+func TestUpdateAlarmState_ClearAcknowledges(t *testing.T) {
+	clock := newFakeClock()
+	svc := newService(t, services.WithClock(clock))
+	ctx := services.WithAudit(context.Background(), services.Audit{Actor: "operator-1"})
+
+	unacked, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Pump Failure", State: models.Triggered})
+	clock.Advance(time.Minute)
+	cleared, err := svc.UpdateAlarmState(ctx, unacked.ID, models.Cleared)
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if cleared.Acknowledgement != models.Acknowledged || cleared.ACKedBy != "operator-1" || cleared.ACKedAt != clock.Now().Format(time.RFC3339) {
+		t.Errorf("expected clearing to acknowledge as operator-1 now, got %+v", cleared)
+	}
+
+	acked, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Valve Stuck", State: models.Triggered})
+	acked, _ = svc.AcknowledgeAlarm(ctx, acked.ID, models.AckRequest{})
+	clock.Advance(time.Minute)
+	cleared, _ = svc.UpdateAlarmState(services.WithAudit(context.Background(), services.Audit{Actor: "operator-2"}), acked.ID, models.Cleared)
+	if cleared.ACKedBy != "operator-1" || cleared.ACKedAt != acked.ACKedAt {
+		t.Errorf("expected the earlier acknowledgement to be kept, got %+v", cleared)
+	}
+}
+
+// TestShelveAlarm verifies shelving pauses reminders, hides the alarm from default listings
+// and is lifted automatically when it expires.
+func TestShelveAlarm(t *testing.T) {
+	clock := newFakeClock()
+	svc := newService(t, services.WithClock(clock))
+	start := clock.Now()
+
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Chattering Sensor", State: models.Triggered, Severity: models.Critical})
+	ctx := services.WithAudit(context.Background(), services.Audit{Actor: "operator-1"})
+
+	for _, request := range []models.ShelveRequest{
+		{Duration: "30m"},
+		{Reason: "nuisance"},
+		{Duration: "30m", Until: start.Add(time.Hour).Format(time.RFC3339), Reason: "nuisance"},
+		{Duration: "48h", Reason: "nuisance"},
+		{Until: start.Add(-time.Minute).Format(time.RFC3339), Reason: "nuisance"},
+	} {
+		if _, err := svc.ShelveAlarm(ctx, alarm.ID, request); !errors.Is(err, services.ErrValidation) {
+			t.Errorf("expected validation error for %+v, got %v", request, err)
+		}
+	}
+
+	alarm, err := svc.ShelveAlarm(ctx, alarm.ID, models.ShelveRequest{Duration: "30m", Reason: "sensor chattering"})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if alarm.ISAState != models.ISAShelved || alarm.ShelvedBy != "operator-1" || alarm.ShelvedUntil != start.Add(30*time.Minute).Format(time.RFC3339) {
+		t.Errorf("expected alarm shelved for 30 minutes by operator-1, got %+v", alarm)
+	}
+	if page, _ := svc.ListAlarms(context.Background(), models.AlarmQuery{}); len(page.Alarms) != 0 {
+		t.Errorf("expected shelved alarm excluded from default listings, got %d", len(page.Alarms))
+	}
+	if page, _ := svc.ListAlarms(context.Background(), models.AlarmQuery{Shelved: models.ShelvedOnly}); len(page.Alarms) != 1 {
+		t.Errorf("expected shelved alarm to be listable, got %d", len(page.Alarms))
+	}
+
+	waitFor(t, func() bool { return clock.timerPending(start.Add(30 * time.Minute)) })
+	clock.Advance(30 * time.Minute)
+	waitFor(t, func() bool {
+		alarm, _ := svc.GetAlarmByID(alarm.ID)
+		return alarm.Suppression == models.NotSuppressed
+	})
+
+	schedule, _ := svc.GetNextNotification(alarm.ID)
+	if schedule.NextNotificationAt != start.Add(35*time.Minute).Format(time.RFC3339) {
+		t.Errorf("expected reminders resumed after unshelve, got %+v", schedule)
+	}
+	history, _ := svc.GetAlarmHistory(alarm.ID)
+	if last := history.Transitions[len(history.Transitions)-1]; last.Reason != models.ReasonUnshelved || last.Via != "scheduler" {
+		t.Errorf("expected automatic unshelve recorded, got %+v", last)
+	}
+
+	if _, err := svc.UnshelveAlarm(ctx, alarm.ID); !errors.Is(err, services.ErrNotShelved) {
+		t.Errorf("expected ErrNotShelved, got %v", err)
+	}
+	svc.ShelveAlarm(ctx, alarm.ID, models.ShelveRequest{Duration: "1h", Reason: "maintenance"})
+	if alarm, err := svc.UnshelveAlarm(ctx, alarm.ID); err != nil || alarm.ShelvedUntil != "" {
+		t.Errorf("expected manual unshelve, got %+v, %v", alarm, err)
+	}
+}
+
+// TestSilences verifies silenced alarms are stored without being notified until the silence expires.
+func TestSilences(t *testing.T) {
+	clock := newFakeClock()
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry))
+	ctx := services.WithAudit(context.Background(), services.Audit{Actor: "operator-1"})
+	start := clock.Now()
+
+	for _, silence := range []models.Silence{
+		{EndsAt: start.Add(time.Hour).Format(time.RFC3339)},
+		{Matchers: []models.Matcher{{Name: "team", Value: "(", IsRegex: true}}, EndsAt: start.Add(time.Hour).Format(time.RFC3339)},
+		{Matchers: []models.Matcher{{Name: "team", Value: "storage"}}},
+		{Matchers: []models.Matcher{{Name: "team", Value: "storage"}}, EndsAt: start.Add(-time.Hour).Format(time.RFC3339)},
+		{Matchers: []models.Matcher{{Name: "team", Value: "storage"}}, Recurrence: &models.Recurrence{StartTime: "02:00", EndTime: "02:00"}},
+	} {
+		if _, err := svc.CreateSilence(ctx, silence); !errors.Is(err, services.ErrValidation) {
+			t.Errorf("expected validation error for %+v, got %v", silence, err)
+		}
+	}
+
+	silence, err := svc.CreateSilence(ctx, models.Silence{
+		Matchers: []models.Matcher{{Name: "team", Value: "storage|db", IsRegex: true}, {Name: models.MatchSeverity, Value: "Critical"}},
+		EndsAt:   start.Add(time.Hour).Format(time.RFC3339),
+		Comment:  "storage maintenance",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if silence.Status != models.SilenceActive || silence.CreatedBy != "operator-1" || silence.StartsAt != start.Format(time.RFC3339) {
+		t.Errorf("expected active silence created by operator-1 starting now, got %+v", silence)
+	}
+
+	silenced, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Disk Failure", State: models.Triggered, Severity: models.Critical, Labels: map[string]string{"team": "db"}})
+	notified, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Disk Failure", State: models.Triggered, Severity: models.Major, Labels: map[string]string{"team": "db"}})
+	waitFor(t, func() bool { return len(recorder.received()) == 1 })
+	if received := recorder.received()[0]; received.Alarm.ID != notified.ID {
+		t.Errorf("expected only the unsilenced alarm notified, got %+v", received.Alarm)
+	}
+	if _, err := svc.GetAlarmByID(silenced.ID); err != nil {
+		t.Errorf("expected silenced alarm to be stored, got %v", err)
+	}
+
+	if _, err := svc.ExpireSilence(ctx, "unknown"); !errors.Is(err, services.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+	silence, err = svc.ExpireSilence(ctx, silence.ID)
+	if err != nil || silence.Status != models.SilenceExpired {
+		t.Fatalf("expected expired silence, got %+v, %v", silence, err)
+	}
+	if silences := svc.ListSilences(); len(silences) != 1 || silences[0].Status != models.SilenceExpired {
+		t.Errorf("expected expired silence to be listed, got %+v", silences)
+	}
+
+	svc.UpdateAlarmState(ctx, silenced.ID, models.ACKed)
+	waitFor(t, func() bool { return len(recorder.received()) == 2 })
+	if received := recorder.received()[1]; received.Alarm.ID != silenced.ID {
+		t.Errorf("expected alarm notified once the silence expired, got %+v", received.Alarm)
+	}
+}
+
+// TestSilences_Recurring verifies a recurring silence only applies within its window.
+func TestSilences_Recurring(t *testing.T) {
+	clock := newFakeClock() // Monday 10:00 UTC, 11:00 in Berlin
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry))
+
+	_, err := svc.CreateSilence(context.Background(), models.Silence{
+		Matchers:   []models.Matcher{{Name: models.MatchAlarmName, Value: "Backup Job Failed"}},
+		Recurrence: &models.Recurrence{Weekdays: []string{"Monday"}, StartTime: "10:30", EndTime: "12:00", TimeZone: "Europe/Berlin"},
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	svc.CreateAlarm(context.Background(), models.Alarm{Name: "Backup Job Failed", State: models.Triggered, Severity: models.Minor})
+	clock.Advance(time.Hour)
+	alarm, _ := svc.CreateAlarm(context.Background(), models.Alarm{Name: "Backup Job Failed", State: models.Triggered, Severity: models.Minor})
+
+	waitFor(t, func() bool { return len(recorder.received()) == 1 })
+	if received := recorder.received()[0]; received.Alarm.ID != alarm.ID {
+		t.Errorf("expected only the alarm raised outside the window notified, got %+v", received.Alarm)
+	}
+}
+
+// TestInhibition verifies target alarms are marked inhibited and not notified while a source
+// alarm is open, and are released and notified once it clears.
+func TestInhibition(t *testing.T) {
+	recorder := &recordingNotifier{name: "recorder"}
+	registry, _ := notify.NewRegistry(recorder)
+	svc := newService(t, services.WithNotifiers(registry), services.WithInhibitRules(models.InhibitRule{
+		SourceMatchers: []models.Matcher{{Name: models.MatchAlarmName, Value: "Core Switch Down"}},
+		TargetMatchers: []models.Matcher{{Name: models.MatchAlarmName, Value: "Network Latency"}},
+		Equal:          []string{"site"},
+	}))
+	ctx := context.Background()
+	site := map[string]string{"site": "fra"}
+
+	latency, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Network Latency", State: models.Triggered, Severity: models.Major, Labels: site})
+	source, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Core Switch Down", State: models.Triggered, Severity: models.Critical, Labels: site})
+	target, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Network Latency", State: models.Triggered, Severity: models.Major, Labels: site})
+	other, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Network Latency", State: models.Triggered, Severity: models.Major, Labels: map[string]string{"site": "ams"}})
+
+	if !slices.Equal(target.InhibitedBy, []string{source.ID}) {
+		t.Errorf("expected new target inhibited by the source, got %v", target.InhibitedBy)
+	}
+	if latency, _ = svc.GetAlarmByID(latency.ID); !slices.Equal(latency.InhibitedBy, []string{source.ID}) {
+		t.Errorf("expected existing target inhibited once the source opened, got %v", latency.InhibitedBy)
+	}
+	if len(other.InhibitedBy) != 0 {
+		t.Errorf("expected alarm at another site not inhibited, got %v", other.InhibitedBy)
+	}
+
+	svc.UpdateAlarmState(ctx, target.ID, models.ACKed)
+	if target, _ = svc.GetAlarmByID(target.ID); len(target.InhibitedBy) != 1 {
+		t.Errorf("expected acknowledged target still inhibited, got %v", target.InhibitedBy)
+	}
+
+	svc.UpdateAlarmState(ctx, source.ID, models.ACKed)
+	if target, _ = svc.GetAlarmByID(target.ID); len(target.InhibitedBy) != 0 {
+		t.Errorf("expected target released once the source was acknowledged, got %v", target.InhibitedBy)
+	}
+
+	waitFor(t, func() bool { return len(recorder.received()) == 6 })
+	var targetReasons []models.NotificationReason
+	for _, notification := range recorder.received() {
+		if notification.Alarm.ID == target.ID {
+			targetReasons = append(targetReasons, notification.Reason)
+		}
+	}
+	if !slices.Equal(targetReasons, []models.NotificationReason{models.ReasonInhibitionReleased}) {
+		t.Errorf("expected target notified only on release, got %v", targetReasons)
+	}
+
+	history, _ := svc.GetAlarmHistory(latency.ID)
+	reasons := make([]models.NotificationReason, 0, len(history.Transitions))
+	for _, transition := range history.Transitions {
+		reasons = append(reasons, transition.Reason)
+	}
+	if !slices.Equal(reasons, []models.NotificationReason{models.ReasonCreated, models.ReasonInhibited, models.ReasonInhibitionReleased}) {
+		t.Errorf("expected inhibition recorded in history, got %v", reasons)
+	}
+
+	relocated, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Core Switch Down", State: models.Triggered, Severity: models.Critical, Labels: map[string]string{"site": "ams"}})
+	if other, _ = svc.GetAlarmByID(other.ID); !slices.Equal(other.InhibitedBy, []string{relocated.ID}) {
+		t.Errorf("expected alarm at another site inhibited by its own source, got %v", other.InhibitedBy)
+	}
+	svc.DeleteAlarm(ctx, relocated.ID)
+	if other, _ = svc.GetAlarmByID(other.ID); len(other.InhibitedBy) != 0 {
+		t.Errorf("expected target released once the source was deleted, got %v", other.InhibitedBy)
+	}
+}
+
+// TestInhibition_ReconciledOnStartup verifies inhibitions persisted before a restart are re-evaluated against the rules in effect.
+func TestInhibition_ReconciledOnStartup(t *testing.T) {
+	dir := t.TempDir()
+	rule := models.InhibitRule{
+		SourceMatchers: []models.Matcher{{Name: models.MatchAlarmName, Value: "Core Switch Down"}},
+		TargetMatchers: []models.Matcher{{Name: models.MatchAlarmName, Value: "Network Latency"}},
+	}
+	ctx := context.Background()
+
+	st, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	svc := newService(t, services.WithStore(st), services.WithInhibitRules(rule))
+	svc.CreateAlarm(ctx, models.Alarm{Name: "Core Switch Down", State: models.Triggered})
+	target, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Network Latency", State: models.Triggered})
+	if len(target.InhibitedBy) != 1 {
+		t.Fatalf("expected target inhibited before the restart, got %v", target.InhibitedBy)
+	}
+	svc.Close(ctx)
+
+	reopened, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	svc = newService(t, services.WithStore(reopened))
+	defer svc.Close(ctx)
+
+	if target, _ = svc.GetAlarmByID(target.ID); len(target.InhibitedBy) != 0 {
+		t.Errorf("expected target released once its rule was removed, got %v", target.InhibitedBy)
+	}
+	history, _ := svc.GetAlarmHistory(target.ID)
+	if last := history.Transitions[len(history.Transitions)-1]; last.Reason != models.ReasonInhibitionReleased {
+		t.Errorf("expected the release recorded in history, got %+v", last)
+	}
+}
+
+// TestListAlarms_LabelSelector verifies label validation and that selectors are answered
+// from the label index as alarms are created and deleted.
+func TestListAlarms_LabelSelector(t *testing.T) {
+	svc := newService(t)
+	ctx := context.Background()
+
+	_, err := svc.CreateAlarm(ctx, models.Alarm{Name: "Latency", State: models.Triggered, Labels: map[string]string{"host-name": "web-1"}})
+	if !errors.Is(err, services.ErrValidation) {
+		t.Errorf("expected validation error for an invalid label key, got %v", err)
+	}
+
+	hosts := map[string]string{"web-1": "prod", "web-2": "prod", "db-1": "prod", "web-3": "staging"}
+	ids := make(map[string]string)
+	for host, env := range hosts {
+		alarm, err := svc.CreateAlarm(ctx, models.Alarm{
+			Name:        "Latency",
+			State:       models.Triggered,
+			Labels:      map[string]string{"env": env, "host": host},
+			Annotations: map[string]string{"runbook_url": "https://runbooks.example.com/latency"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		ids[host] = alarm.ID
+	}
+	svc.CreateAlarm(ctx, models.Alarm{Name: "Unlabelled", State: models.Triggered})
+
+	hostsOf := func(selector string) []string {
+		t.Helper()
+		matchers, err := models.ParseSelector(selector)
+		if err != nil {
+			t.Fatalf("expected valid selector, got %v", err)
+		}
+		page, _ := svc.ListAlarms(ctx, models.AlarmQuery{Selector: matchers})
+		var result []string
+		for _, alarm := range page.Alarms {
+			result = append(result, alarm.Labels["host"])
+		}
+		slices.Sort(result)
+		return result
+	}
+
+	if got := hostsOf("env=prod,host=~web-.*"); !slices.Equal(got, []string{"web-1", "web-2"}) {
+		t.Errorf("expected prod web hosts, got %v", got)
+	}
+	if got := hostsOf("env!=prod"); len(got) != 2 || !slices.Contains(got, "web-3") || !slices.Contains(got, "") {
+		t.Errorf("expected staging and unlabelled alarms, got %v", got)
+	}
+	if got := hostsOf("host!~web-.*,env=prod"); !slices.Equal(got, []string{"db-1"}) {
+		t.Errorf("expected db-1 only, got %v", got)
+	}
+
+	svc.DeleteAlarm(ctx, ids["web-1"])
+	if got := hostsOf("env=prod,host=~web-.*"); !slices.Equal(got, []string{"web-2"}) {
+		t.Errorf("expected deleted alarm removed from the index, got %v", got)
+	}
+}
+
+// TestEscalation verifies unacknowledged alarms escalate through the tiers of their policy up
+// to the repeat limit, that each step is recorded in their history and that an ACK stops escalation.
+func TestEscalation(t *testing.T) {
+	clock := newFakeClock()
+	primary := &recordingNotifier{name: "primary"}
+	secondary := &recordingNotifier{name: "secondary"}
+	registry, _ := notify.NewRegistry(primary, secondary)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry), services.WithEscalationPolicies(models.EscalationPolicy{
+		Name:     "database",
+		Matchers: []models.Matcher{{Name: "team", Value: "db"}},
+		Tiers: []models.EscalationTier{
+			{Targets: []string{"primary"}, Wait: "10m"},
+			{Targets: []string{"secondary"}, Wait: "10m"},
+		},
+		Repeat: 1,
+	}))
+	ctx := context.Background()
+	start := clock.Now()
+	team := map[string]string{"team": "db"}
+
+	escalated, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Replication Lag", State: models.Triggered, Severity: models.Warning, Labels: team})
+	acked, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Slow Queries", State: models.Triggered, Severity: models.Warning, Labels: team})
+	unassigned, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Disk Full", State: models.Triggered, Severity: models.Warning})
+
+	if escalated.EscalationPolicy != "database" || escalated.EscalateAt != start.Add(10*time.Minute).Format(time.RFC3339) {
+		t.Errorf("expected policy assigned by label and escalation due in 10 minutes, got %+v", escalated)
+	}
+	if unassigned.EscalationPolicy != "" || unassigned.EscalateAt != "" {
+		t.Errorf("expected no policy for unmatched alarm, got %+v", unassigned)
+	}
+	if acked, _ = svc.UpdateAlarmState(ctx, acked.ID, models.ACKed); acked.EscalateAt != "" {
+		t.Errorf("expected acknowledgement to stop escalation, got %+v", acked)
+	}
+
+	for level := 1; level <= 3; level++ {
+		due := start.Add(time.Duration(level) * 10 * time.Minute)
+		waitFor(t, func() bool { return clock.timerPending(due) })
+		clock.Advance(10 * time.Minute)
+		waitFor(t, func() bool {
+			alarm, _ := svc.GetAlarmByID(escalated.ID)
+			return alarm.EscalationLevel == level
+		})
+	}
+	if escalated, _ = svc.GetAlarmByID(escalated.ID); escalated.EscalateAt != "" {
+		t.Errorf("expected escalation to stop after the last repeat, got %+v", escalated)
+	}
+	if acked, _ = svc.GetAlarmByID(acked.ID); acked.EscalationLevel != 0 {
+		t.Errorf("expected acknowledged alarm not escalated, got %+v", acked)
+	}
+
+	waitFor(t, func() bool { return len(primary.received()) == 5 && len(secondary.received()) == 3 })
+	reasons := func(notifier *recordingNotifier) []models.NotificationReason {
+		var reasons []models.NotificationReason
+		for _, notification := range notifier.received() {
+			if notification.Alarm.ID == escalated.ID {
+				reasons = append(reasons, notification.Reason)
+			}
+		}
+		return reasons
+	}
+	if got := reasons(primary); !slices.Equal(got, []models.NotificationReason{models.ReasonCreated, models.ReasonEscalated}) {
+		t.Errorf("expected primary paged on creation and the repeated first tier, got %v", got)
+	}
+	if got := reasons(secondary); !slices.Equal(got, []models.NotificationReason{models.ReasonEscalated, models.ReasonEscalated}) {
+		t.Errorf("expected secondary paged on each second tier, got %v", got)
+	}
+
+	history, _ := svc.GetAlarmHistory(escalated.ID)
+	if len(history.Transitions) != 4 {
+		t.Fatalf("expected creation and 3 escalations recorded, got %+v", history.Transitions)
+	}
+	if last := history.Transitions[3]; last.Reason != models.ReasonEscalated || last.Via != "escalation" {
+		t.Errorf("expected escalation recorded in history, got %+v", last)
+	}
+}
+
+// TestEscalation_InvalidPolicies verifies the service rejects policies without tiers and duplicate policy names.
+func TestEscalation_InvalidPolicies(t *testing.T) {
+	valid := models.EscalationPolicy{Name: "database", Tiers: []models.EscalationTier{{Targets: []string{"console"}, Wait: "10m"}}}
+	for name, policies := range map[string][]models.EscalationPolicy{
+		"no tiers":  {{Name: "database"}},
+		"bad wait":  {{Name: "database", Tiers: []models.EscalationTier{{Targets: []string{"console"}, Wait: "soon"}}}},
+		"duplicate": {valid, valid},
+	} {
+		if _, err := services.NewAlarmService(services.WithEscalationPolicies(policies...)); err == nil {
+			t.Errorf("expected error for %s", name)
+		}
+	}
+}
+
+// TestOnCall verifies who is on call for a schedule, overrides, and that schedule targets
+// are resolved to the users on call when notifying.
+func TestOnCall(t *testing.T) {
+	clock := newFakeClock()
+	pager := &recordingNotifier{name: "pager"}
+	other := &recordingNotifier{name: "other"}
+	registry, _ := notify.NewRegistry(pager, other)
+	svc := newService(t, services.WithClock(clock), services.WithNotifiers(registry),
+		services.WithOnCall(models.OnCallConfig{
+			Users: []models.User{
+				{ID: "alice", Email: "alice@example.com", Notifiers: []string{"pager"}},
+				{ID: "bob", Email: "bob@example.com", Notifiers: []string{"pager"}},
+			},
+			Schedules: []models.Schedule{{ID: "database", Layers: []models.Rotation{
+				{Name: "days", Users: []string{"alice", "bob"}, Handoff: models.HandoffDaily, HandoffTime: "09:00", Start: "2024-01-01T09:00:00Z"},
+			}}},
+		}),
+		services.WithEscalationPolicies(models.EscalationPolicy{
+			Name:  "database",
+			Tiers: []models.EscalationTier{{Targets: []string{models.ScheduleTargetPrefix + "database"}, Wait: "15m"}},
+		}))
+	ctx := services.WithAudit(context.Background(), services.Audit{Actor: "operator-1"})
+	start := clock.Now()
+
+	onCall, err := svc.WhoIsOnCall("database", time.Time{})
+	if err != nil || onCall.User == nil || onCall.User.ID != "alice" || onCall.User.Email != "alice@example.com" {
+		t.Fatalf("expected alice on call, got %+v, %v", onCall, err)
+	}
+	if onCall, _ = svc.WhoIsOnCall("database", start.Add(24*time.Hour)); onCall.User == nil || onCall.User.ID != "bob" {
+		t.Errorf("expected bob on call the next day, got %+v", onCall)
+	}
+	if _, err := svc.WhoIsOnCall("unknown", time.Time{}); !errors.Is(err, services.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for an unknown schedule, got %v", err)
+	}
+
+	for _, invalid := range []models.Override{
+		{UserID: "mallory", EndsAt: start.Add(time.Hour).Format(time.RFC3339)},
+		{UserID: "bob"},
+		{UserID: "bob", EndsAt: start.Add(-time.Hour).Format(time.RFC3339)},
+	} {
+		if _, err := svc.CreateOverride(ctx, "database", invalid); !errors.Is(err, services.ErrValidation) {
+			t.Errorf("expected validation error for %+v, got %v", invalid, err)
+		}
+	}
+
+	svc.CreateAlarm(ctx, models.Alarm{Name: "Replication Lag", State: models.Triggered, Severity: models.Warning})
+	waitFor(t, func() bool { return len(pager.received()) == 1 })
+	override, err := svc.CreateOverride(ctx, "database", models.Override{UserID: "bob", EndsAt: start.Add(time.Hour).Format(time.RFC3339)})
+	if err != nil || override.CreatedBy != "operator-1" || override.StartsAt != start.Format(time.RFC3339) {
+		t.Fatalf("expected override starting now by operator-1, got %+v, %v", override, err)
+	}
+	if onCall, _ = svc.WhoIsOnCall("database", time.Time{}); onCall.User == nil || onCall.User.ID != "bob" || onCall.OverrideID != override.ID {
+		t.Errorf("expected bob on call through the override, got %+v", onCall)
+	}
+	svc.CreateAlarm(ctx, models.Alarm{Name: "Slow Queries", State: models.Triggered, Severity: models.Warning})
+
+	waitFor(t, func() bool { return len(pager.received()) == 2 })
+	var recipients []string
+	for _, notification := range pager.received() {
+		for _, user := range notification.Recipients {
+			recipients = append(recipients, user.ID)
+		}
+	}
+	if !slices.Equal(recipients, []string{"alice", "bob"}) {
+		t.Errorf("expected schedule resolved to alice, then bob, got %v", recipients)
+	}
+	if received := other.received(); len(received) != 0 {
+		t.Errorf("expected notifier outside the tier not to be notified, got %d", len(received))
+	}
+
+	if err := svc.DeleteOverride(ctx, "database", override.ID); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := svc.DeleteOverride(ctx, "database", override.ID); !errors.Is(err, services.ErrNotFound) {
+		t.Errorf("expected ErrNotFound for a deleted override, got %v", err)
+	}
+	if overrides, _ := svc.ListOverrides("database"); len(overrides) != 0 {
+		t.Errorf("expected no overrides left, got %+v", overrides)
+	}
+}
+
+// TestRouting verifies notifications are delivered to the receivers of the routes their alarm
+// matches and that a sample alarm can be routed without being created.
+func TestRouting(t *testing.T) {
+	pager := &recordingNotifier{name: "pager"}
+	chat := &recordingNotifier{name: "chat"}
+	registry, _ := notify.NewRegistry(pager, chat)
+	svc := newService(t, services.WithNotifiers(registry), services.WithRoutes(models.Route{
+		Receivers: []string{"chat"},
+		Routes: []models.Route{
+			{Name: "critical", Matchers: []models.Matcher{{Name: models.MatchSeverity, Value: "Critical"}}, Receivers: []string{"pager"}, Continue: true},
+			{Name: "database", Matchers: []models.Matcher{{Name: "team", Value: "db"}}, Receivers: []string{"chat"}},
+		},
+	}), services.WithEscalationPolicies(models.EscalationPolicy{
+		Name:     "storage",
+		Matchers: []models.Matcher{{Name: "team", Value: "storage"}},
+		Tiers:    []models.EscalationTier{{Targets: []string{"pager"}, Wait: "15m"}},
+	}))
+	ctx := context.Background()
+
+	outage, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Datacenter Outage", State: models.Triggered, Severity: models.Critical})
+	lag, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Replication Lag", State: models.Triggered, Severity: models.Critical, Labels: map[string]string{"team": "db"}})
+	disk, _ := svc.CreateAlarm(ctx, models.Alarm{Name: "Disk Full", State: models.Triggered, Severity: models.Minor})
+
+	waitFor(t, func() bool { return len(pager.received()) == 2 && len(chat.received()) == 2 })
+	ids := func(notifier *recordingNotifier) []string {
+		var ids []string
+		for _, notification := range notifier.received() {
+			ids = append(ids, notification.Alarm.ID)
+		}
+		return ids
+	}
+	if got := ids(pager); !slices.Equal(got, []string{outage.ID, lag.ID}) {
+		t.Errorf("expected critical alarms paged, got %v", got)
+	}
+	if got := ids(chat); !slices.Equal(got, []string{lag.ID, disk.ID}) {
+		t.Errorf("expected database and default alarms posted to chat, got %v", got)
+	}
+
+	result, err := svc.RouteAlarm(models.Alarm{Name: "Replication Lag", State: models.Triggered, Severity: models.Critical, Labels: map[string]string{"team": "db"}})
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if !slices.Equal(result.Receivers, []string{"pager", "chat"}) || len(result.Routes) != 2 || result.Routes[1].Route != "database" {
+		t.Errorf("expected sample routed through critical and database, got %+v", result)
+	}
+	result, _ = svc.RouteAlarm(models.Alarm{Name: "Replication Lag", State: models.Cleared, Severity: models.Critical, Labels: map[string]string{"team": "db"}})
+	if len(result.Receivers) != 0 {
+		t.Errorf("expected a cleared sample to reach no receivers, got %+v", result)
+	}
+	result, _ = svc.RouteAlarm(models.Alarm{Name: "Disk Full", State: models.Triggered, Severity: models.Minor, Labels: map[string]string{"team": "storage"}})
+	if result.EscalationPolicy != "storage" || len(result.Routes) != 0 || !slices.Equal(result.Receivers, []string{"pager"}) {
+		t.Errorf("expected sample with an escalation policy to page its first tier, got %+v", result)
+	}
+	if _, err := svc.RouteAlarm(models.Alarm{State: models.Triggered}); !errors.Is(err, services.ErrValidation) {
+		t.Errorf("expected validation error for a sample without a name, got %v", err)
+	}
+	if page, _ := svc.ListAlarms(ctx, models.AlarmQuery{}); len(page.Alarms) != 3 {
+		t.Errorf("expected the sample not to be created, got %d alarms", len(page.Alarms))
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+)
+
+// ErrRolledBack is the error of the valid items of an atomic bulk request that was not
+// applied because another of its items failed.
+var ErrRolledBack = errors.New("not applied: another item of the atomic request failed")
+
+// BulkOptions configures a bulk operation.
+type BulkOptions struct {
+	Atomic bool // Apply either every item or, if any item fails, none of them
+}
+
+// BulkResult is the outcome of a single item of a bulk operation.
+type BulkResult struct {
+	Index int          // Position of the item in the request
+	Alarm models.Alarm // Alarm as created, updated or deleted; empty if the item failed
+	Err   error        // Why the item failed, nil on success
+}
+
+// bulkBatch accumulates the results, store mutations and notifications of a bulk
+// operation so that they are applied in a single store batch.
+type bulkBatch struct {
+	results       []BulkResult
+	ops           []store.Op
+	notifications []models.Notification
+	failed        bool
+}
+
+// succeed records a successful item.
+func (b *bulkBatch) succeed(index int, alarm models.Alarm, ops ...store.Op) {
+	b.results = append(b.results, BulkResult{Index: index, Alarm: alarm})
+	b.ops = append(b.ops, ops...)
+}
+
+// fail records a failed item.
+func (b *bulkBatch) fail(index int, err error) {
+	b.results = append(b.results, BulkResult{Index: index, Err: err})
+	b.failed = true
+}
+
+// commit applies the batch and queues its notifications. In atomic mode a batch with a
+// failed item is not applied and its successful items are marked ErrRolledBack.
+// Callers must hold the write lock.
+func (s *AlarmService) commit(batch *bulkBatch, opts BulkOptions) ([]BulkResult, error) {
+	if opts.Atomic && batch.failed {
+		for i := range batch.results {
+			if batch.results[i].Err == nil {
+				batch.results[i] = BulkResult{Index: batch.results[i].Index, Err: ErrRolledBack}
+			}
+		}
+		return batch.results, nil
+	}
+
+	if err := s.apply(batch.ops...); err != nil {
+		return nil, err
+	}
+	for _, notification := range batch.notifications {
+		s.notify(notification)
+	}
+	return batch.results, nil
+}
+
+// CreateAlarms creates alarms in a single store batch and reports the outcome of each.
+// An error is returned only if the batch as a whole could not be applied.
+//
+// An alarm whose fingerprint matches an open alarm, including one created earlier in the
+// batch, is a repeat: it increments the occurrences of that alarm and updates its last
+// seen time, and only notifies if it raises the severity. A repeat of an alarm cleared
+// within the reopen window reopens it; later repeats create a new instance.
+func (s *AlarmService) CreateAlarms(ctx context.Context, alarms []models.Alarm, opts BulkOptions) ([]BulkResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	audit := auditFrom(ctx)
+	now := s.clock.Now()
+	pending := make(map[string]models.Alarm)
+	batch := &bulkBatch{}
+	for index, alarm := range alarms {
+		if err := s.validateAlarm(alarm); err != nil {
+			batch.fail(index, err)
+			continue
+		}
+
+		fingerprint := alarm.Fingerprint()
+		if existing, found := s.findDuplicate(fingerprint, pending); found {
+			if merged, ops, notification, ok := s.mergeDuplicate(audit, existing, alarm.Severity, now); ok {
+				pending[fingerprint] = merged
+				batch.succeed(index, merged, ops...)
+				if notification != nil {
+					batch.notifications = append(batch.notifications, *notification)
+				}
+				continue
+			}
+		}
+
+		s.initializeAlarm(&alarm)
+		alarm = s.withInhibition(alarm)
+		alarm = s.withEscalation(alarm, now)
+		if fingerprint != "" {
+			pending[fingerprint] = alarm
+		}
+		batch.succeed(index, alarm, s.createOps(audit, alarm, now)...)
+		batch.notifications = append(batch.notifications, models.Notification{Alarm: alarm, Reason: models.ReasonCreated})
+	}
+
+	results, err := s.commit(batch, opts)
+	if err == nil && (!opts.Atomic || !batch.failed) {
+		for fingerprint, alarm := range pending {
+			s.dedupIndex[fingerprint] = alarm.ID
+		}
+	}
+	return results, err
+}
+
+// UpdateAlarmStates applies lifecycle state updates in a single store batch and reports
+// the outcome of each. Updates are applied in order, so an alarm may appear more than once.
+func (s *AlarmService) UpdateAlarmStates(ctx context.Context, updates []models.StateUpdate, opts BulkOptions) ([]BulkResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	audit := auditFrom(ctx)
+	now := s.clock.Now()
+	pending := make(map[string]models.Alarm)
+	batch := &bulkBatch{}
+	for index, update := range updates {
+		if !update.State.IsValid() {
+			batch.fail(index, ErrInvalidState)
+			continue
+		}
+		alarm, found := pending[update.ID]
+		if !found {
+			if alarm, found = s.store.Get(update.ID); !found {
+				batch.fail(index, ErrNotFound)
+				continue
+			}
+		}
+
+		updated, err := withState(alarm, update.State, audit, now)
+		if err != nil {
+			batch.fail(index, err)
+			continue
+		}
+		updated, ops := s.transitionOps(updated, now)
+		pending[update.ID] = updated
+		batch.succeed(index, updated, append(ops, recordOp(audit, updated, alarm.State, models.ReasonStateChanged, now))...)
+		batch.notifications = append(batch.notifications, models.Notification{Alarm: updated, PreviousState: alarm.State, Reason: models.ReasonStateChanged})
+	}
+	return s.commit(batch, opts)
+}
+
+// DeleteAlarms deletes alarms in a single store batch and reports the outcome of each.
+func (s *AlarmService) DeleteAlarms(ctx context.Context, ids []string, opts BulkOptions) ([]BulkResult, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	audit := auditFrom(ctx)
+	now := s.clock.Now()
+	var deleted []string
+	batch := &bulkBatch{}
+	for index, id := range ids {
+		alarm, found := s.store.Get(id)
+		if !found || slices.Contains(deleted, id) {
+			batch.fail(index, ErrNotFound)
+			continue
+		}
+		deleted = append(deleted, id)
+		batch.succeed(index, alarm, store.DeleteAlarm(id), deleteRecordOp(audit, alarm, now))
+	}
+
+	results, err := s.commit(batch, opts)
+	if err == nil && (!opts.Atomic || !batch.failed) {
+		s.forgetDeliveries(deleted...)
+	}
+	return results, err
+}
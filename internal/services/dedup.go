@@ -0,0 +1,85 @@
+package services
+
+import (
+	"cmp"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+)
+
+// DefaultReopenWindow is how long after being cleared an alarm is reopened, rather than
+// raised as a new instance, when its source sends it again, unless overridden with
+// WithReopenWindow.
+const DefaultReopenWindow = 15 * time.Minute
+
+// buildDedupIndex maps the fingerprint of every stored alarm to the ID of its most
+// recently created instance.
+func buildDedupIndex(alarms []models.Alarm) map[string]string {
+	index := make(map[string]string)
+	created := make(map[string]string)
+	for _, alarm := range alarms {
+		if alarm.DedupKey == "" {
+			continue
+		}
+		if latest, exists := created[alarm.DedupKey]; !exists || alarm.CreatedAt >= latest {
+			index[alarm.DedupKey] = alarm.ID
+			created[alarm.DedupKey] = alarm.CreatedAt
+		}
+	}
+	return index
+}
+
+// findDuplicate returns the latest instance of the alarm with the given fingerprint,
+// preferring one already updated by the current batch. Callers must hold the write lock.
+func (s *AlarmService) findDuplicate(fingerprint string, pending map[string]models.Alarm) (models.Alarm, bool) {
+	if fingerprint == "" {
+		return models.Alarm{}, false
+	}
+	if alarm, found := pending[fingerprint]; found {
+		return alarm, true
+	}
+
+	id, indexed := s.dedupIndex[fingerprint]
+	if !indexed {
+		return models.Alarm{}, false
+	}
+	alarm, found := s.store.Get(id)
+	if !found {
+		delete(s.dedupIndex, fingerprint) // Instance was deleted
+		return models.Alarm{}, false
+	}
+	return alarm, true
+}
+
+// mergeDuplicate records a repeat of existing raised with the given severity. An open alarm
+// counts the occurrence and is only notified if the severity escalates; a Cleared alarm is
+// reopened if it was cleared within the reopen window. It reports false if the repeat must be
+// raised as a new instance instead.
+func (s *AlarmService) mergeDuplicate(audit Audit, existing models.Alarm, severity models.Severity, now time.Time) (models.Alarm, []store.Op, *models.Notification, bool) {
+	alarm := existing
+	alarm.Occurrences++
+	alarm.LastSeen = now.Format(time.RFC3339)
+	escalated := severity.MoreSevereThan(alarm.Severity)
+	if escalated {
+		alarm.Severity = severity
+	}
+
+	if alarm.State == models.Cleared {
+		// Alarms cleared before ClearedAt was recorded fall back to their last update.
+		clearedAt, err := time.Parse(time.RFC3339, cmp.Or(alarm.ClearedAt, alarm.UpdatedAt))
+		if err != nil || now.Sub(clearedAt) > s.reopenWindow {
+			return models.Alarm{}, nil, nil, false
+		}
+		alarm, ops := s.transitionOps(reopened(alarm), now)
+		ops = append(ops, recordOp(audit, alarm, existing.State, models.ReasonReopened, now))
+		return alarm, ops, &models.Notification{Alarm: alarm, PreviousState: existing.State, Reason: models.ReasonReopened}, true
+	}
+
+	if !escalated {
+		return alarm, []store.Op{store.PutAlarm(alarm)}, nil, true
+	}
+	alarm, ops := s.transitionOps(alarm, now)
+	ops = append(ops, recordOp(audit, alarm, existing.State, models.ReasonSeverityEscalated, now))
+	return alarm, ops, &models.Notification{Alarm: alarm, PreviousState: existing.State, Reason: models.ReasonSeverityEscalated}, true
+}
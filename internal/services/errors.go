@@ -0,0 +1,114 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+var (
+	// ErrNotFound is returned when the requested alarm does not exist.
+	ErrNotFound = errors.New("alarm not found")
+	// ErrValidation is wrapped by every *ValidationError, so errors.Is(err, ErrValidation)
+	// reports whether a request was rejected because of invalid input.
+	ErrValidation = errors.New("validation failed")
+	// ErrConflict is wrapped by errors reporting a request that conflicts with the current
+	// state of an alarm, such as a *TransitionError.
+	ErrConflict = errors.New("conflict")
+	// ErrClosed is returned by mutations of an AlarmService that has been closed.
+	ErrClosed = errors.New("alarm service is closed")
+	// ErrNotShelvable is returned when shelving an alarm that is cleared or otherwise suppressed.
+	ErrNotShelvable = fmt.Errorf("%w: only open alarms that are not suppressed can be shelved", ErrConflict)
+	// ErrNotShelved is returned when unshelving an alarm that is not shelved.
+	ErrNotShelved = fmt.Errorf("%w: alarm is not shelved", ErrConflict)
+	// ErrSilenceNotFound is returned when the requested silence does not exist. It matches ErrNotFound.
+	ErrSilenceNotFound error = notFoundError("silence not found")
+	// ErrScheduleNotFound is returned when the requested on-call schedule does not exist. It matches ErrNotFound.
+	ErrScheduleNotFound error = notFoundError("schedule not found")
+	// ErrOverrideNotFound is returned when the requested on-call override does not exist. It matches ErrNotFound.
+	ErrOverrideNotFound error = notFoundError("override not found")
+
+	// ErrInvalidState is returned for a lifecycle state that does not exist.
+	ErrInvalidState error = &ValidationError{Field: "state", Message: "invalid alarm state"}
+	// ErrInvalidQuery is returned by ListAlarms for a query with an unknown sort field or a negative limit.
+	ErrInvalidQuery error = &ValidationError{Field: "query", Message: "invalid alarm query"}
+	// ErrInvalidCursor is returned by ListAlarms for a cursor that is malformed or was issued for a different ordering.
+	ErrInvalidCursor error = &ValidationError{Field: "cursor", Message: "invalid cursor"}
+)
+
+// ValidationError reports an invalid field of a request.
+type ValidationError struct {
+	Field   string // JSON name of the invalid field
+	Message string // Human-readable description of the problem
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return e.Message
+}
+
+// Unwrap returns ErrValidation.
+func (e *ValidationError) Unwrap() error {
+	return ErrValidation
+}
+
+// notFoundError reports a missing resource other than an alarm.
+type notFoundError string
+
+// Error implements the error interface.
+func (e notFoundError) Error() string {
+	return string(e)
+}
+
+// Is reports whether target is ErrNotFound.
+func (e notFoundError) Is(target error) bool {
+	return target == ErrNotFound
+}
+
+// TransitionError reports an update that the alarm lifecycle does not allow.
+type TransitionError struct {
+	From models.AlarmState
+	To   models.AlarmState
+}
+
+// Error implements the error interface.
+func (e *TransitionError) Error() string {
+	return fmt.Sprintf("cannot transition alarm from %s to %s", e.From, e.To)
+}
+
+// Unwrap returns ErrConflict.
+func (e *TransitionError) Unwrap() error {
+	return ErrConflict
+}
+
+// BulkItemError is the error of a single item of a bulk request.
+type BulkItemError struct {
+	Index int    // Position of the item in the request
+	Name  string // Name of the alarm, if any
+	Err   error  // Why the item failed
+}
+
+// BulkError reports the items of a bulk request that failed. The other items succeeded.
+type BulkError struct {
+	Items []BulkItemError
+}
+
+// Error implements the error interface.
+func (e *BulkError) Error() string {
+	messages := make([]string, 0, len(e.Items))
+	for _, item := range e.Items {
+		messages = append(messages, fmt.Sprintf("item %d (%s): %v", item.Index, item.Name, item.Err))
+	}
+	return fmt.Sprintf("%d items failed: %s", len(e.Items), strings.Join(messages, "; "))
+}
+
+// Unwrap returns the errors of the failed items.
+func (e *BulkError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Items))
+	for _, item := range e.Items {
+		errs = append(errs, item.Err)
+	}
+	return errs
+}
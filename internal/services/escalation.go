@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+)
+
+// escalationVia identifies changes made when an unacknowledged alarm escalates.
+const escalationVia = "escalation"
+
+// WithEscalationPolicies sets the escalation policies assigned to new alarms. An alarm is
+// assigned the first policy whose matchers it satisfies; alarms without a policy notify
+// every notifier and never escalate. No policy is assigned when this option is omitted.
+// NewAlarmService fails if a policy is invalid or two policies share a name.
+func WithEscalationPolicies(policies ...models.EscalationPolicy) Option {
+	return func(s *AlarmService) {
+		s.escalationPolicies = policies
+	}
+}
+
+// escalating reports whether an alarm is awaiting acknowledgement and presented to operators,
+// so that it escalates when its tier times out.
+func escalating(alarm models.Alarm) bool {
+	return alarm.Acknowledgement == models.Unacknowledged && alarm.State != models.Cleared && alarm.Suppression == models.NotSuppressed
+}
+
+// escalationPolicy returns the policy assigned to an alarm or, if none is assigned yet,
+// the first policy matching it.
+func (s *AlarmService) escalationPolicy(alarm models.Alarm) (models.EscalationPolicy, bool) {
+	for _, policy := range s.escalationPolicies {
+		if alarm.EscalationPolicy == policy.Name || alarm.EscalationPolicy == "" && models.MatchAll(policy.Matchers, alarm) {
+			return policy, true
+		}
+	}
+	return models.EscalationPolicy{}, false
+}
+
+// withEscalation returns alarm with its escalation stopped once it is acknowledged, cleared
+// or suppressed, and otherwise assigned a policy and due to escalate after the wait of its
+// current tier, unless it has reached the last one.
+func (s *AlarmService) withEscalation(alarm models.Alarm, now time.Time) models.Alarm {
+	if !escalating(alarm) {
+		alarm.EscalateAt = ""
+		return alarm
+	}
+	if alarm.EscalateAt != "" {
+		return alarm
+	}
+
+	policy, found := s.escalationPolicy(alarm)
+	if !found {
+		return alarm
+	}
+	if alarm.EscalationPolicy == "" {
+		alarm.EscalationPolicy = policy.Name
+		alarm.EscalationLevel = 0
+	}
+	if alarm.EscalationLevel+1 < policy.Steps() {
+		alarm.EscalateAt = now.Add(policy.Tier(alarm.EscalationLevel).WaitDuration()).Format(time.RFC3339)
+	}
+	return alarm
+}
+
+// withoutEscalation returns alarm with its escalation reset, so that it is assigned a policy
+// and escalates from the first tier again.
+func withoutEscalation(alarm models.Alarm) models.Alarm {
+	alarm.EscalationPolicy = ""
+	alarm.EscalationLevel = 0
+	alarm.EscalateAt = ""
+	return alarm
+}
+
+// escalationTargets returns the names of the notifiers paged at the current tier of an
+// alarm, or false if the alarm has no escalation policy and notifies every notifier.
+func (s *AlarmService) escalationTargets(alarm models.Alarm) ([]string, bool) {
+	if alarm.EscalationPolicy == "" {
+		return nil, false
+	}
+	policy, found := s.escalationPolicy(alarm)
+	if !found {
+		return nil, false
+	}
+	return policy.Tier(alarm.EscalationLevel).Targets, true
+}
+
+// scheduleEscalation mirrors the escalation due time of a stored alarm into the escalation scheduler.
+func (s *AlarmService) scheduleEscalation(alarm models.Alarm) {
+	if at, err := time.Parse(time.RFC3339, alarm.EscalateAt); err == nil {
+		s.escalations.schedule(alarm.ID, at)
+		return
+	}
+	s.escalations.cancel(alarm.ID)
+}
+
+// escalateAlarms is called by the escalation scheduler with the IDs of alarms whose tier
+// timed out. Each alarm still awaiting acknowledgement moves to the next tier, whose targets
+// are notified, and the step is recorded in its history.
+func (s *AlarmService) escalateAlarms(ids []string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.closed {
+		return
+	}
+
+	now := s.clock.Now()
+	for _, id := range ids {
+		alarm, found := s.store.Get(id)
+		if !found {
+			continue
+		}
+		if at, err := time.Parse(time.RFC3339, alarm.EscalateAt); err != nil || at.After(now) || !escalating(alarm) {
+			continue
+		}
+		policy, found := s.escalationPolicy(alarm)
+		if !found {
+			continue
+		}
+
+		alarm.EscalationLevel++
+		alarm.EscalateAt = ""
+		alarm = s.withEscalation(alarm, now)
+		alarm.UpdatedAt = now.Format(time.RFC3339)
+		tier := policy.Tier(alarm.EscalationLevel)
+		audit := Audit{
+			Via:     escalationVia,
+			Comment: fmt.Sprintf("escalated to tier %d of %s, paging %s", alarm.EscalationLevel%len(policy.Tiers)+1, policy.Name, strings.Join(tier.Targets, ", ")),
+		}
+		if err := s.apply(store.PutAlarm(alarm), recordOp(audit, alarm, alarm.State, models.ReasonEscalated, now)); err != nil {
+			log.Printf("failed to escalate alarm %s: %v", alarm.ID, err)
+			continue
+		}
+		s.notify(models.Notification{Alarm: alarm, PreviousState: alarm.State, Reason: models.ReasonEscalated})
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+)
+
+// defaultVia identifies changes made by calling the service directly rather than through the API.
+const defaultVia = "service"
+
+// Audit describes who made a change and through which interface; it is recorded in the
+// history of every alarm the change affects.
+type Audit struct {
+	Actor   string // Who made the change, if known
+	Comment string // Free-text comment supplied with the change
+	Via     string // API route or component that made the change
+}
+
+// withAck attributes the change to the user and comment of an acknowledgement request,
+// keeping the request actor when no user is given.
+func (a Audit) withAck(ack models.AckRequest) Audit {
+	if ack.User != "" {
+		a.Actor = ack.User
+	}
+	a.Comment = ack.Comment
+	return a
+}
+
+// auditKey is the context key under which the Audit of a request is stored.
+type auditKey struct{}
+
+// WithAudit returns a copy of ctx carrying audit, to be recorded by the mutations called with it.
+func WithAudit(ctx context.Context, audit Audit) context.Context {
+	return context.WithValue(ctx, auditKey{}, audit)
+}
+
+// auditFrom returns the Audit carried by ctx, attributing the change to the service itself
+// when no interface is set.
+func auditFrom(ctx context.Context) Audit {
+	audit, _ := ctx.Value(auditKey{}).(Audit)
+	if audit.Via == "" {
+		audit.Via = defaultVia
+	}
+	return audit
+}
+
+// recordOp returns the store mutation that appends a change of alarm from the given
+// lifecycle state to its history.
+func recordOp(audit Audit, alarm models.Alarm, from models.AlarmState, reason models.NotificationReason, now time.Time) store.Op {
+	return store.Record(models.Transition{
+		AlarmID:  alarm.ID,
+		At:       now.Format(time.RFC3339),
+		From:     from,
+		To:       alarm.State,
+		ISAState: alarm.ISAState,
+		Reason:   reason,
+		Actor:    audit.Actor,
+		Comment:  audit.Comment,
+		Via:      audit.Via,
+	})
+}
+
+// deleteRecordOp returns the store mutation that records the deletion of alarm in its history.
+func deleteRecordOp(audit Audit, alarm models.Alarm, now time.Time) store.Op {
+	op := recordOp(audit, alarm, alarm.State, models.ReasonDeleted, now)
+	op.Transition.To = ""
+	op.Transition.ISAState = ""
+	return op
+}
+
+// GetAlarmHistory returns the audit trail of an alarm, which is retained after the alarm is
+// deleted, together with its time to acknowledge and time to clear.
+func (s *AlarmService) GetAlarmHistory(id string) (models.AlarmHistory, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	transitions := s.store.History(id)
+	_, found := s.store.Get(id)
+	if len(transitions) == 0 && !found {
+		return models.AlarmHistory{}, ErrNotFound
+	}
+
+	history := models.AlarmHistory{AlarmID: id, Deleted: !found, Transitions: transitions}
+	if len(transitions) > 0 {
+		raisedAt, err := time.Parse(time.RFC3339, transitions[0].At)
+		if err == nil {
+			history.TimeToAcknowledgeSeconds = secondsUntil(raisedAt, transitions, func(t models.Transition) bool {
+				return t.To == models.ACKed || t.To == models.Cleared
+			})
+			history.TimeToClearSeconds = secondsUntil(raisedAt, transitions, func(t models.Transition) bool {
+				return t.To == models.Cleared
+			})
+		}
+	}
+	return history, nil
+}
+
+// secondsUntil returns the seconds from raisedAt to the first transition matching reached,
+// or nil if no transition matches.
+func secondsUntil(raisedAt time.Time, transitions []models.Transition, reached func(models.Transition) bool) *int64 {
+	for _, transition := range transitions {
+		if !reached(transition) {
+			continue
+		}
+		at, err := time.Parse(time.RFC3339, transition.At)
+		if err != nil {
+			return nil
+		}
+		seconds := int64(at.Sub(raisedAt) / time.Second)
+		return &seconds
+	}
+	return nil
+}
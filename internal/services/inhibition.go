@@ -0,0 +1,206 @@
+package services
+
+import (
+	"log"
+	"maps"
+	"slices"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+)
+
+// inhibitionVia identifies changes of the inhibition of an alarm caused by its source alarms.
+const inhibitionVia = "inhibition"
+
+// WithInhibitRules sets the rules under which open source alarms withhold the notifications
+// of their target alarms. Invalid rules never match. No alarm is inhibited when this option
+// is omitted.
+func WithInhibitRules(rules ...models.InhibitRule) Option {
+	return func(s *AlarmService) {
+		s.inhibitRules = rules
+	}
+}
+
+// inhibiting reports whether an alarm inhibits the targets of its rules: only while it is
+// Triggered or Active, so acknowledging or clearing it releases them.
+func inhibiting(alarm models.Alarm) bool {
+	return alarm.State == models.Triggered || alarm.State == models.Active
+}
+
+// isInhibitionSource reports whether an alarm matches the source matchers of any rule and
+// is Triggered or Active.
+func (s *AlarmService) isInhibitionSource(alarm models.Alarm) bool {
+	return inhibiting(alarm) && slices.ContainsFunc(s.inhibitRules, func(rule models.InhibitRule) bool { return rule.IsSource(alarm) })
+}
+
+// isInhibitionTarget reports whether an alarm matches the target matchers of any rule or is
+// marked as inhibited, so that its inhibition may change when a source does.
+func (s *AlarmService) isInhibitionTarget(alarm models.Alarm) bool {
+	return len(alarm.InhibitedBy) > 0 || slices.ContainsFunc(s.inhibitRules, func(rule models.InhibitRule) bool {
+		return models.MatchAll(rule.TargetMatchers, alarm)
+	})
+}
+
+// indexInhibitions builds the indexes of inhibition sources and targets from alarms.
+func (s *AlarmService) indexInhibitions(alarms []models.Alarm) {
+	s.sources = make(map[string]models.Alarm)
+	s.targets = make(map[string]struct{})
+	for _, alarm := range alarms {
+		if s.isInhibitionSource(alarm) {
+			s.sources[alarm.ID] = alarm
+		}
+		if s.isInhibitionTarget(alarm) {
+			s.targets[alarm.ID] = struct{}{}
+		}
+	}
+}
+
+// inhibitors returns the sorted IDs of the indexed sources that inhibit an alarm. Cleared
+// alarms are never inhibited.
+func (s *AlarmService) inhibitors(alarm models.Alarm) []string {
+	if alarm.State == models.Cleared {
+		return nil
+	}
+	var ids []string
+	for _, source := range s.sources {
+		if slices.ContainsFunc(s.inhibitRules, func(rule models.InhibitRule) bool { return rule.Inhibits(source, alarm) }) {
+			ids = append(ids, source.ID)
+		}
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// withInhibition returns alarm marked as inhibited by the indexed source alarms. Callers must
+// hold the lock.
+func (s *AlarmService) withInhibition(alarm models.Alarm) models.Alarm {
+	if len(s.inhibitRules) == 0 {
+		return alarm
+	}
+	alarm.InhibitedBy = s.inhibitors(alarm)
+	return alarm
+}
+
+// sameMatchedFields reports whether two alarms have the same state and the same value for every
+// field and label a matcher or an Equal name can select.
+func sameMatchedFields(a, b models.Alarm) bool {
+	return a.Name == b.Name && a.Severity == b.Severity && a.State == b.State && a.Source == b.Source && maps.Equal(a.Labels, b.Labels)
+}
+
+// indexOps updates the source and target indexes with the alarms changed or deleted by ops and
+// returns the IDs of the sources that started or stopped inhibiting or changed a matched
+// field. Callers must hold the write lock.
+func (s *AlarmService) indexOps(ops []store.Op) map[string]struct{} {
+	changed := make(map[string]struct{})
+	for _, op := range ops {
+		switch op.Kind {
+		case store.OpPutAlarm:
+			alarm := *op.Alarm
+			previous, wasSource := s.sources[alarm.ID]
+			isSource := s.isInhibitionSource(alarm)
+			if isSource {
+				s.sources[alarm.ID] = alarm
+			} else {
+				delete(s.sources, alarm.ID)
+			}
+			if wasSource != isSource || (isSource && !sameMatchedFields(previous, alarm)) {
+				changed[alarm.ID] = struct{}{}
+			}
+			if s.isInhibitionTarget(alarm) {
+				s.targets[alarm.ID] = struct{}{}
+			} else {
+				delete(s.targets, alarm.ID)
+			}
+		case store.OpDeleteAlarm:
+			if _, wasSource := s.sources[op.ID]; wasSource {
+				delete(s.sources, op.ID)
+				changed[op.ID] = struct{}{}
+			}
+			delete(s.targets, op.ID)
+		}
+	}
+	return changed
+}
+
+// updateInhibitions re-evaluates the inhibition of the indexed targets of the sources that ops
+// opened, cleared, deleted or changed. Callers must hold the write lock.
+func (s *AlarmService) updateInhibitions(ops []store.Op) {
+	if len(s.inhibitRules) == 0 {
+		return
+	}
+	changed := s.indexOps(ops)
+	if len(changed) == 0 {
+		return
+	}
+
+	// A target is affected if a changed source inhibited it before or inhibits it now.
+	var affected []models.Alarm
+	for id := range s.targets {
+		alarm, found := s.store.Get(id)
+		if !found {
+			continue
+		}
+		for source := range changed {
+			if slices.Contains(alarm.InhibitedBy, source) || s.inhibits(source, alarm) {
+				affected = append(affected, alarm)
+				break
+			}
+		}
+	}
+	s.reinhibit(affected)
+}
+
+// reconcileInhibitions re-evaluates the inhibition of every stored alarm, so that inhibitions
+// persisted before a restart reflect the sources and rules now in effect. Callers must hold
+// the write lock or be constructing the service.
+func (s *AlarmService) reconcileInhibitions() {
+	s.reinhibit(s.store.List())
+}
+
+// inhibits reports whether the indexed source with the given ID inhibits an alarm.
+func (s *AlarmService) inhibits(id string, alarm models.Alarm) bool {
+	source, isSource := s.sources[id]
+	return isSource && slices.ContainsFunc(s.inhibitRules, func(rule models.InhibitRule) bool { return rule.Inhibits(source, alarm) })
+}
+
+// reinhibit updates and records the inhibition of the alarms whose inhibitors changed. Alarms
+// whose last source cleared are notified, unless they are cleared themselves.
+func (s *AlarmService) reinhibit(alarms []models.Alarm) {
+	audit := Audit{Via: inhibitionVia}
+	now := s.clock.Now()
+	var updates []store.Op
+	var released []models.Notification
+	for _, alarm := range alarms {
+		ids := s.inhibitors(alarm)
+		if slices.Equal(ids, alarm.InhibitedBy) {
+			continue
+		}
+
+		alarm.InhibitedBy = ids
+		reason := models.ReasonInhibited
+		if len(ids) == 0 {
+			reason = models.ReasonInhibitionReleased
+			if alarm.State != models.Cleared {
+				released = append(released, models.Notification{Alarm: alarm, PreviousState: alarm.State, Reason: reason})
+			}
+		}
+		updates = append(updates, store.PutAlarm(alarm), recordOp(audit, alarm, alarm.State, reason, now))
+	}
+	if len(updates) == 0 {
+		return
+	}
+
+	if err := s.apply(updates...); err != nil {
+		log.Printf("failed to update alarm inhibitions: %v", err)
+		return
+	}
+	for _, notification := range released {
+		s.notify(notification)
+	}
+}
+
+// inhibited reports whether the stored alarm is inhibited. Callers must hold the lock.
+func (s *AlarmService) inhibited(id string) bool {
+	alarm, found := s.store.Get(id)
+	return found && len(alarm.InhibitedBy) > 0
+}
@@ -0,0 +1,93 @@
+package services
+
+import (
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// labelIndex maps label names and values to the IDs of the alarms carrying them, so that
+// listings filtered by label do not scan every alarm. It is guarded by the service lock.
+type labelIndex struct {
+	ids    map[string]map[string]map[string]struct{} // Label name to value to alarm IDs
+	labels map[string]map[string]string              // Alarm ID to its indexed labels
+}
+
+// newLabelIndex returns an index of the labels of alarms.
+func newLabelIndex(alarms []models.Alarm) *labelIndex {
+	index := &labelIndex{
+		ids:    make(map[string]map[string]map[string]struct{}),
+		labels: make(map[string]map[string]string),
+	}
+	for _, alarm := range alarms {
+		index.put(alarm)
+	}
+	return index
+}
+
+// put indexes the labels of an alarm, replacing those indexed for it before.
+func (x *labelIndex) put(alarm models.Alarm) {
+	x.remove(alarm.ID)
+	if len(alarm.Labels) == 0 {
+		return
+	}
+
+	labels := make(map[string]string, len(alarm.Labels))
+	for name, value := range alarm.Labels {
+		values, exists := x.ids[name]
+		if !exists {
+			values = make(map[string]map[string]struct{})
+			x.ids[name] = values
+		}
+		if values[value] == nil {
+			values[value] = make(map[string]struct{})
+		}
+		values[value][alarm.ID] = struct{}{}
+		labels[name] = value
+	}
+	x.labels[alarm.ID] = labels
+}
+
+// remove drops the labels indexed for an alarm.
+func (x *labelIndex) remove(id string) {
+	for name, value := range x.labels[id] {
+		ids := x.ids[name][value]
+		delete(ids, id)
+		if len(ids) == 0 {
+			delete(x.ids[name], value)
+		}
+		if len(x.ids[name]) == 0 {
+			delete(x.ids, name)
+		}
+	}
+	delete(x.labels, id)
+}
+
+// candidates returns the IDs of the alarms that may satisfy every matcher, narrowed by the
+// most selective matcher that only alarms carrying its label can satisfy. It reports false
+// when no matcher narrows the listing, in which case every alarm has to be considered.
+func (x *labelIndex) candidates(matchers []models.Matcher) (map[string]struct{}, bool) {
+	var best map[string]struct{}
+	narrowed := false
+	for _, matcher := range matchers {
+		if matcher.MatchesValue("") || !matcher.IsLabel() {
+			continue // Satisfied by alarms without the label, or not a label
+		}
+
+		var ids map[string]struct{}
+		if !matcher.IsRegex && !matcher.IsNegative {
+			ids = x.ids[matcher.Name][matcher.Value]
+		} else {
+			ids = make(map[string]struct{})
+			for value, valueIDs := range x.ids[matcher.Name] {
+				if matcher.MatchesValue(value) {
+					for id := range valueIDs {
+						ids[id] = struct{}{}
+					}
+				}
+			}
+		}
+		if !narrowed || len(ids) < len(best) {
+			best, narrowed = ids, true
+		}
+	}
+	return best, narrowed
+}
@@ -0,0 +1,213 @@
+package services
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+	"github.com/google/uuid"
+)
+
+// WithOnCall sets the users and schedules of the on-call subsystem. Escalation targets
+// prefixed with models.ScheduleTargetPrefix page whoever is on call for the named schedule
+// through the notifiers of that user. No schedule is configured when this option is omitted.
+func WithOnCall(config models.OnCallConfig) Option {
+	return func(s *AlarmService) {
+		for _, user := range config.Users {
+			s.users[user.ID] = user
+		}
+		for _, schedule := range config.Schedules {
+			s.schedules[schedule.ID] = schedule
+		}
+	}
+}
+
+// ListUsers returns the users who can be put on call, ordered by ID.
+func (s *AlarmService) ListUsers() []models.User {
+	users := make([]models.User, 0, len(s.users))
+	for _, user := range s.users {
+		users = append(users, user)
+	}
+	slices.SortFunc(users, func(a, b models.User) int { return strings.Compare(a.ID, b.ID) })
+	return users
+}
+
+// ListSchedules returns the on-call schedules, ordered by ID.
+func (s *AlarmService) ListSchedules() []models.Schedule {
+	schedules := make([]models.Schedule, 0, len(s.schedules))
+	for _, schedule := range s.schedules {
+		schedules = append(schedules, schedule)
+	}
+	slices.SortFunc(schedules, func(a, b models.Schedule) int { return strings.Compare(a.ID, b.ID) })
+	return schedules
+}
+
+// GetSchedule retrieves an on-call schedule by its ID.
+func (s *AlarmService) GetSchedule(id string) (models.Schedule, error) {
+	schedule, found := s.schedules[id]
+	if !found {
+		return models.Schedule{}, ErrScheduleNotFound
+	}
+	return schedule, nil
+}
+
+// WhoIsOnCall returns who is on call for a schedule at the given time, or now if at is zero.
+func (s *AlarmService) WhoIsOnCall(id string, at time.Time) (models.OnCall, error) {
+	schedule, err := s.GetSchedule(id)
+	if err != nil {
+		return models.OnCall{}, err
+	}
+	if at.IsZero() {
+		at = s.clock.Now()
+	}
+	return s.onCallAt(schedule, at), nil
+}
+
+// CreateOverride stores an override that puts a user on call for a schedule in place of its
+// rotations. The start defaults to now and the creator to the actor of ctx.
+func (s *AlarmService) CreateOverride(ctx context.Context, scheduleID string, override models.Override) (models.Override, error) {
+	if _, err := s.GetSchedule(scheduleID); err != nil {
+		return models.Override{}, err
+	}
+	now := s.clock.Now()
+	if override.StartsAt == "" {
+		override.StartsAt = now.Format(time.RFC3339)
+	}
+	if err := s.validateOverride(override); err != nil {
+		return models.Override{}, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	override.ID = uuid.New().String()
+	override.ScheduleID = scheduleID
+	override.CreatedAt = now.Format(time.RFC3339Nano)
+	if override.CreatedBy == "" {
+		override.CreatedBy = auditFrom(ctx).Actor
+	}
+	if err := s.apply(store.PutOverride(override)); err != nil {
+		return models.Override{}, err
+	}
+	return override, nil
+}
+
+// ListOverrides returns the overrides of a schedule, including past ones, ordered by start time.
+func (s *AlarmService) ListOverrides(scheduleID string) ([]models.Override, error) {
+	if _, err := s.GetSchedule(scheduleID); err != nil {
+		return nil, err
+	}
+
+	s.overrideLock.RLock()
+	overrides := make([]models.Override, 0)
+	for _, override := range s.overrides {
+		if override.ScheduleID == scheduleID {
+			overrides = append(overrides, override)
+		}
+	}
+	s.overrideLock.RUnlock()
+
+	slices.SortFunc(overrides, func(a, b models.Override) int {
+		if c := strings.Compare(a.StartsAt, b.StartsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return overrides, nil
+}
+
+// DeleteOverride removes an override of a schedule, handing back to its rotations.
+func (s *AlarmService) DeleteOverride(ctx context.Context, scheduleID, id string) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.overrideLock.RLock()
+	override, found := s.overrides[id]
+	s.overrideLock.RUnlock()
+	if !found || override.ScheduleID != scheduleID {
+		return ErrOverrideNotFound
+	}
+	return s.apply(store.DeleteOverride(id))
+}
+
+// onCallAt returns who is on call for a schedule at t, resolved to the configured user.
+func (s *AlarmService) onCallAt(schedule models.Schedule, t time.Time) models.OnCall {
+	s.overrideLock.RLock()
+	overrides := make([]models.Override, 0, len(s.overrides))
+	for _, override := range s.overrides {
+		overrides = append(overrides, override)
+	}
+	s.overrideLock.RUnlock()
+
+	onCall := schedule.OnCallAt(t, overrides)
+	if onCall.User != nil {
+		if user, found := s.users[onCall.User.ID]; found {
+			onCall.User = &user
+		}
+	}
+	return onCall
+}
+
+// onCallRecipients resolves the schedule targets among the given escalation targets to the
+// users on call now, keyed by the names of the notifiers that page them.
+func (s *AlarmService) onCallRecipients(targets []string) map[string][]models.User {
+	recipients := make(map[string][]models.User)
+	now := s.clock.Now()
+	for _, target := range targets {
+		id, isSchedule := strings.CutPrefix(target, models.ScheduleTargetPrefix)
+		if !isSchedule {
+			continue
+		}
+		schedule, found := s.schedules[id]
+		if !found {
+			log.Printf("unknown on-call schedule %s", id)
+			continue
+		}
+		onCall := s.onCallAt(schedule, now)
+		if onCall.User == nil {
+			log.Printf("nobody is on call for schedule %s", id)
+			continue
+		}
+		for _, notifier := range onCall.User.Notifiers {
+			if !slices.ContainsFunc(recipients[notifier], func(user models.User) bool { return user.ID == onCall.User.ID }) {
+				recipients[notifier] = append(recipients[notifier], *onCall.User)
+			}
+		}
+	}
+	return recipients
+}
+
+// putOverride mirrors a stored override into the overrides consulted when notifying.
+func (s *AlarmService) putOverride(override models.Override) {
+	s.overrideLock.Lock()
+	defer s.overrideLock.Unlock()
+
+	s.overrides[override.ID] = override
+}
+
+// deleteOverride removes a deleted override from the overrides consulted when notifying.
+func (s *AlarmService) deleteOverride(id string) {
+	s.overrideLock.Lock()
+	defer s.overrideLock.Unlock()
+
+	delete(s.overrides, id)
+}
+
+// validateOverride verifies the user and period of an override.
+func (s *AlarmService) validateOverride(override models.Override) error {
+	if _, found := s.users[override.UserID]; !found {
+		return &ValidationError{Field: "user_id", Message: "unknown user"}
+	}
+	startsAt, err := time.Parse(time.RFC3339, override.StartsAt)
+	if err != nil {
+		return &ValidationError{Field: "starts_at", Message: "invalid starts_at, expected an RFC 3339 timestamp"}
+	}
+	if endsAt, err := time.Parse(time.RFC3339, override.EndsAt); err != nil || !endsAt.After(startsAt) {
+		return &ValidationError{Field: "ends_at", Message: "invalid ends_at, expected an RFC 3339 timestamp after starts_at"}
+	}
+	return nil
+}
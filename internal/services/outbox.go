@@ -0,0 +1,201 @@
+package services
+
+import (
+	"log"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/notify"
+)
+
+// defaultNotificationQueueSize bounds the number of notifications pending in the outbox
+// and in each notifier queue when WithNotificationQueueSize is omitted.
+const defaultNotificationQueueSize = 10000
+
+// boundedQueue is a FIFO queue holding at most capacity items. Pushing never blocks:
+// when the queue is full or closed the item is dropped and counted, so producers holding
+// the service lock are never stalled by slow consumers.
+type boundedQueue[T any] struct {
+	name     string
+	capacity int
+	ready    chan struct{}
+
+	lock     sync.Mutex
+	items    []T
+	enqueued uint64
+	dropped  uint64
+	full     bool
+	closed   bool
+}
+
+// newBoundedQueue initializes an empty queue holding at most capacity items.
+func newBoundedQueue[T any](name string, capacity int) *boundedQueue[T] {
+	return &boundedQueue[T]{
+		name:     name,
+		capacity: capacity,
+		ready:    make(chan struct{}, 1),
+	}
+}
+
+// push appends item to the queue, reporting false if it was dropped because the queue is full
+// or closed.
+func (q *boundedQueue[T]) push(item T) bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if q.closed {
+		q.dropped++
+		return false
+	}
+	if len(q.items) >= q.capacity {
+		q.dropped++
+		if !q.full {
+			q.full = true
+			log.Printf("notification queue %s is full (%d pending), dropping notifications", q.name, len(q.items))
+		}
+		return false
+	}
+
+	q.items = append(q.items, item)
+	q.enqueued++
+	select {
+	case q.ready <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// pop removes and returns the oldest item, reporting false if the queue is empty.
+func (q *boundedQueue[T]) pop() (T, bool) {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	var item T
+	if len(q.items) == 0 {
+		return item, false
+	}
+	item = q.items[0]
+	var zero T
+	q.items[0] = zero
+	q.items = q.items[1:]
+	q.full = false
+	return item, true
+}
+
+// close stops the queue from accepting items. Items already queued are still drained.
+func (q *boundedQueue[T]) close() {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	if !q.closed {
+		q.closed = true
+		close(q.ready)
+	}
+}
+
+// isClosed reports whether close has been called.
+func (q *boundedQueue[T]) isClosed() bool {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return q.closed
+}
+
+// drain calls handle for every item in order, waiting for new items once the queue is empty.
+// It returns once the queue is closed and empty.
+func (q *boundedQueue[T]) drain(handle func(T)) {
+	for {
+		item, ok := q.pop()
+		if !ok {
+			if q.isClosed() {
+				return
+			}
+			<-q.ready
+			continue
+		}
+		handle(item)
+	}
+}
+
+// stats returns the current occupancy and counters of the queue.
+func (q *boundedQueue[T]) stats() models.QueueStats {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+
+	return models.QueueStats{
+		Name:     q.name,
+		Pending:  len(q.items),
+		Capacity: q.capacity,
+		Enqueued: q.enqueued,
+		Dropped:  q.dropped,
+	}
+}
+
+// delivery is a notification waiting to be delivered through a single notifier.
+type delivery struct {
+	notifier     notify.Notifier
+	notification models.Notification
+}
+
+// notify queues a notification in the outbox unless the alarm is inhibited or an active
+// silence matches it. It never blocks; callers must hold the service lock and the
+// notification is dispatched by the outbox worker.
+func (s *AlarmService) notify(notification models.Notification) {
+	if s.inhibited(notification.Alarm.ID) || s.silenced(notification.Alarm) {
+		return
+	}
+	s.outbox.push(notification)
+}
+
+// notifierQueue returns the delivery queue of a notifier, starting its worker on first use.
+// Every notifier has its own queue and worker so that a slow or retrying notifier only
+// backs up its own deliveries.
+func (s *AlarmService) notifierQueue(name string) *boundedQueue[delivery] {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	queue, exists := s.notifierQueues[name]
+	if !exists {
+		queue = newBoundedQueue[delivery](name, s.queueSize)
+		s.notifierQueues[name] = queue
+		s.workers.Add(1)
+		go func() {
+			defer s.workers.Done()
+			queue.drain(func(d delivery) { s.deliver(d.notifier, d.notification) })
+		}()
+	}
+	return queue
+}
+
+// closeNotifierQueues closes every notifier queue so that their workers exit once drained.
+func (s *AlarmService) closeNotifierQueues() {
+	s.queueLock.Lock()
+	defer s.queueLock.Unlock()
+
+	for _, queue := range s.notifierQueues {
+		queue.close()
+	}
+}
+
+// NotificationStats reports the occupancy of the outbox and of every notifier queue,
+// including the number of notifications dropped because a queue was full.
+func (s *AlarmService) NotificationStats() models.NotificationStats {
+	s.queueLock.Lock()
+	queues := make([]*boundedQueue[delivery], 0, len(s.notifierQueues))
+	for _, queue := range s.notifierQueues {
+		queues = append(queues, queue)
+	}
+	s.queueLock.Unlock()
+
+	stats := models.NotificationStats{
+		Outbox:    s.outbox.stats(),
+		Notifiers: make([]models.QueueStats, 0, len(queues)),
+	}
+	for _, queue := range queues {
+		stats.Notifiers = append(stats.Notifiers, queue.stats())
+	}
+	slices.SortFunc(stats.Notifiers, func(a, b models.QueueStats) int { return strings.Compare(a.Name, b.Name) })
+	return stats
+}
@@ -0,0 +1,148 @@
+package services
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// maxQueryLimit caps the page size of alarm listings.
+const maxQueryLimit = 1000
+
+// defaultQueryLimit is the page size of alarm listings that do not set a limit.
+const defaultQueryLimit = 100
+
+// pageCursor is the decoded form of an opaque pagination cursor: the position of the last
+// alarm of a page within the ordering it was issued for.
+type pageCursor struct {
+	SortBy     models.SortField `json:"s"`
+	Descending bool             `json:"d,omitempty"`
+	At         time.Time        `json:"t"`
+	ID         string           `json:"id"`
+}
+
+// encodeCursor returns the opaque cursor following alarm in the ordering of query.
+func encodeCursor(query models.AlarmQuery, alarm models.Alarm) string {
+	data, _ := json.Marshal(pageCursor{
+		SortBy:     query.SortBy,
+		Descending: query.Descending,
+		At:         query.SortBy.Timestamp(alarm),
+		ID:         alarm.ID,
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodeCursor parses an opaque cursor, verifying it was issued for the ordering of query.
+func decodeCursor(query models.AlarmQuery) (pageCursor, error) {
+	var cursor pageCursor
+	data, err := base64.RawURLEncoding.DecodeString(query.Cursor)
+	if err != nil || json.Unmarshal(data, &cursor) != nil {
+		return pageCursor{}, ErrInvalidCursor
+	}
+	if cursor.SortBy != query.SortBy || cursor.Descending != query.Descending || cursor.ID == "" {
+		return pageCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
+
+// candidates returns the alarms that may match query, looked up in the label index when the
+// query filters by label. Callers must hold the lock.
+func (s *AlarmService) candidates(query models.AlarmQuery) []models.Alarm {
+	ids, narrowed := s.labelIndex.candidates(query.LabelMatchers())
+	if !narrowed {
+		return s.store.List()
+	}
+
+	alarms := make([]models.Alarm, 0, len(ids))
+	for id := range ids {
+		if alarm, found := s.store.Get(id); found {
+			alarms = append(alarms, alarm)
+		}
+	}
+	return alarms
+}
+
+// ListAlarms returns the page of alarms matching query, ordered by the query's sort field
+// and then by ID so that pagination is stable. Pages hold 100 alarms unless the query sets
+// a limit, and at most 1000. Pass the returned NextCursor as the query Cursor to fetch the
+// following page.
+func (s *AlarmService) ListAlarms(ctx context.Context, query models.AlarmQuery) (models.AlarmPage, error) {
+	if query.SortBy == "" {
+		query.SortBy = models.SortByCreatedAt
+	}
+	if !query.SortBy.IsValid() || query.Limit < 0 {
+		return models.AlarmPage{}, ErrInvalidQuery
+	}
+	switch {
+	case query.Limit == 0:
+		query.Limit = defaultQueryLimit
+	case query.Limit > maxQueryLimit:
+		query.Limit = maxQueryLimit
+	}
+
+	var cursor pageCursor
+	if query.Cursor != "" {
+		var err error
+		if cursor, err = decodeCursor(query); err != nil {
+			return models.AlarmPage{}, err
+		}
+	}
+
+	s.lock.RLock()
+	all := s.candidates(query)
+	s.lock.RUnlock()
+
+	if err := ctx.Err(); err != nil {
+		return models.AlarmPage{}, err
+	}
+
+	type sortable struct {
+		at    time.Time
+		alarm models.Alarm
+	}
+	matches := make([]sortable, 0)
+	for _, alarm := range all {
+		if query.Matches(alarm) {
+			matches = append(matches, sortable{at: query.SortBy.Timestamp(alarm), alarm: alarm})
+		}
+	}
+
+	compare := func(at time.Time, id string, other sortable) int {
+		order := cmp.Or(at.Compare(other.at), strings.Compare(id, other.alarm.ID))
+		if query.Descending {
+			return -order
+		}
+		return order
+	}
+	slices.SortFunc(matches, func(a, b sortable) int { return compare(a.at, a.alarm.ID, b) })
+
+	start := 0
+	if query.Cursor != "" {
+		start, _ = slices.BinarySearchFunc(matches, cursor, func(item sortable, target pageCursor) int {
+			return -compare(target.At, target.ID, item)
+		})
+		if start < len(matches) && matches[start].alarm.ID == cursor.ID {
+			start++
+		}
+	}
+
+	end := len(matches)
+	if start+query.Limit < end {
+		end = start + query.Limit
+	}
+
+	page := models.AlarmPage{Alarms: make([]models.Alarm, 0, end-start)}
+	for _, item := range matches[start:end] {
+		page.Alarms = append(page.Alarms, item.alarm)
+	}
+	if end < len(matches) {
+		page.NextCursor = encodeCursor(query, matches[end-1].alarm)
+	}
+	return page, nil
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// WithRoutes sets the routing tree that decides which receivers the notifications of alarms
+// without an escalation policy are delivered to. When this option is omitted, every
+// notification is delivered to every registered notifier.
+func WithRoutes(root models.Route) Option {
+	return func(s *AlarmService) {
+		s.routes = &root
+	}
+}
+
+// Routes returns the routing tree, or the default route delivering to every registered
+// notifier if none is configured.
+func (s *AlarmService) Routes() models.Route {
+	if s.routes != nil {
+		return *s.routes
+	}
+	route := models.Route{Name: "default"}
+	for _, notifier := range s.notifiers.Notifiers() {
+		route.Receivers = append(route.Receivers, notifier.Name())
+	}
+	return route
+}
+
+// RouteAlarm reports the receivers the first notification of a sample alarm would reach,
+// without creating it. The alarm is validated and initialized as if it were created and then
+// moved to its requested state, so that escalation policies and the notification intervals
+// apply as they do on delivery. Alarms with an escalation policy report it instead of routes,
+// and alarms whose combined state is not notified reach no receivers.
+func (s *AlarmService) RouteAlarm(alarm models.Alarm) (models.RoutingResult, error) {
+	if err := s.validateAlarm(alarm); err != nil {
+		return models.RoutingResult{}, err
+	}
+
+	now := s.clock.Now()
+	state := alarm.State
+	s.initializeAlarm(&alarm)
+	alarm = s.withEscalation(alarm, now)
+	if state != alarm.State {
+		moved, err := withState(alarm, state, Audit{}, now)
+		if err != nil {
+			return models.RoutingResult{}, err
+		}
+		alarm = s.withEscalation(moved, now)
+		alarm.ISAState = alarm.CombinedState()
+	}
+
+	result := s.Routes().Match(alarm)
+	if _, escalated := s.escalationTargets(alarm); escalated {
+		result.Routes = []models.RouteMatch{}
+		result.EscalationPolicy = alarm.EscalationPolicy
+	}
+	result.Receivers = []string{}
+	if _, notified := notificationInterval(alarm); notified {
+		result.Receivers = append(result.Receivers, s.notificationTargets(alarm)...)
+	}
+	return result, nil
+}
+
+// notificationTargets returns the receivers of a notification: the targets of the current
+// escalation tier for alarms with an escalation policy, and otherwise the receivers of the
+// routes the alarm matches.
+func (s *AlarmService) notificationTargets(alarm models.Alarm) []string {
+	if targets, escalated := s.escalationTargets(alarm); escalated {
+		return targets
+	}
+	return s.Routes().Match(alarm).Receivers
+}
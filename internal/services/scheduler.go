@@ -0,0 +1,205 @@
+package services
+
+import (
+	"container/heap"
+	"sync"
+	"time"
+)
+
+// Clock abstracts the passage of time so that scheduling can be tested without waiting.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// NewTimer returns a Timer that fires once after d.
+	NewTimer(d time.Duration) Timer
+}
+
+// Timer is a single-shot timer created by a Clock.
+type Timer interface {
+	// C returns the channel the fire time is delivered on.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing, reporting whether it was still pending.
+	Stop() bool
+}
+
+// realClock is the Clock backed by the time package.
+type realClock struct{}
+
+func (realClock) Now() time.Time { return time.Now() }
+
+func (realClock) NewTimer(d time.Duration) Timer { return realTimer{time.NewTimer(d)} }
+
+// realTimer adapts *time.Timer to the Timer interface.
+type realTimer struct {
+	timer *time.Timer
+}
+
+func (t realTimer) C() <-chan time.Time { return t.timer.C }
+
+func (t realTimer) Stop() bool { return t.timer.Stop() }
+
+// scheduleEntry is a pending notification in the scheduler heap.
+type scheduleEntry struct {
+	id    string
+	at    time.Time
+	index int
+}
+
+// scheduleHeap is a min-heap of schedule entries ordered by due time.
+type scheduleHeap []*scheduleEntry
+
+func (h scheduleHeap) Len() int { return len(h) }
+
+func (h scheduleHeap) Less(i, j int) bool { return h[i].at.Before(h[j].at) }
+
+func (h scheduleHeap) Swap(i, j int) {
+	h[i], h[j] = h[j], h[i]
+	h[i].index = i
+	h[j].index = j
+}
+
+func (h *scheduleHeap) Push(x any) {
+	entry := x.(*scheduleEntry)
+	entry.index = len(*h)
+	*h = append(*h, entry)
+}
+
+func (h *scheduleHeap) Pop() any {
+	old := *h
+	entry := old[len(old)-1]
+	old[len(old)-1] = nil
+	*h = old[:len(old)-1]
+	entry.index = -1
+	return entry
+}
+
+// scheduler wakes exactly when the earliest scheduled notification is due and
+// hands the due alarm IDs to its fire callback.
+type scheduler struct {
+	clock   Clock
+	fire    func(ids []string)
+	lock    sync.Mutex
+	queue   scheduleHeap
+	entries map[string]*scheduleEntry
+	wake    chan struct{}
+	done    chan struct{}
+}
+
+// newScheduler initializes a scheduler that calls fire with the IDs of due entries.
+func newScheduler(clock Clock, fire func(ids []string)) *scheduler {
+	return &scheduler{
+		clock:   clock,
+		fire:    fire,
+		entries: make(map[string]*scheduleEntry),
+		wake:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+	}
+}
+
+// schedule sets (or moves) the due time of the entry for id.
+func (sch *scheduler) schedule(id string, at time.Time) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	if entry, exists := sch.entries[id]; exists {
+		entry.at = at
+		heap.Fix(&sch.queue, entry.index)
+	} else {
+		entry := &scheduleEntry{id: id, at: at}
+		heap.Push(&sch.queue, entry)
+		sch.entries[id] = entry
+	}
+	sch.signal()
+}
+
+// cancel removes the entry for id, if any.
+func (sch *scheduler) cancel(id string) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	if entry, exists := sch.entries[id]; exists {
+		heap.Remove(&sch.queue, entry.index)
+		delete(sch.entries, id)
+		sch.signal()
+	}
+}
+
+// next returns the due time of the entry for id.
+func (sch *scheduler) next(id string) (time.Time, bool) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	if entry, exists := sch.entries[id]; exists {
+		return entry.at, true
+	}
+	return time.Time{}, false
+}
+
+// signal wakes the run loop so it re-evaluates the earliest due time.
+// Callers must hold the lock.
+func (sch *scheduler) signal() {
+	select {
+	case sch.wake <- struct{}{}:
+	default:
+	}
+}
+
+// popDue removes and returns the IDs of all entries due at or before now, together
+// with the delay until the next entry. A negative delay means the heap is empty.
+func (sch *scheduler) popDue(now time.Time) ([]string, time.Duration) {
+	sch.lock.Lock()
+	defer sch.lock.Unlock()
+
+	var due []string
+	for len(sch.queue) > 0 && !sch.queue[0].at.After(now) {
+		entry := heap.Pop(&sch.queue).(*scheduleEntry)
+		delete(sch.entries, entry.id)
+		due = append(due, entry.id)
+	}
+	if len(sch.queue) == 0 {
+		return due, -1
+	}
+	return due, sch.queue[0].at.Sub(now)
+}
+
+// run fires due entries and sleeps until the next one or until the schedule changes.
+// It returns once stop is called.
+func (sch *scheduler) run() {
+	for {
+		select {
+		case <-sch.done:
+			return
+		default:
+		}
+
+		due, delay := sch.popDue(sch.clock.Now())
+		if len(due) > 0 {
+			sch.fire(due)
+			continue
+		}
+
+		if delay < 0 {
+			select {
+			case <-sch.wake:
+			case <-sch.done:
+				return
+			}
+			continue
+		}
+
+		timer := sch.clock.NewTimer(delay)
+		select {
+		case <-timer.C():
+		case <-sch.wake:
+			timer.Stop()
+		case <-sch.done:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// stop terminates the run loop. Entries are kept so that next keeps reporting them.
+func (sch *scheduler) stop() {
+	close(sch.done)
+}
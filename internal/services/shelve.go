@@ -0,0 +1,108 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// maxShelveDuration bounds how long an alarm may be shelved, so that a nuisance alarm is
+// never silenced indefinitely by a forgotten shelve.
+const maxShelveDuration = 24 * time.Hour
+
+// schedulerVia identifies changes made by the scheduler, such as an expired shelve.
+const schedulerVia = "scheduler"
+
+// ShelveAlarm silences an open alarm for a bounded time without acknowledging or clearing it.
+// Reminders are paused and the alarm is left out of default listings until the shelve
+// expires, when the scheduler unshelves it and resumes its reminders. Shelving a shelved
+// alarm replaces its expiry.
+func (s *AlarmService) ShelveAlarm(ctx context.Context, id string, request models.ShelveRequest) (models.Alarm, error) {
+	now := s.clock.Now()
+	until, err := shelveUntil(request, now)
+	if err != nil {
+		return models.Alarm{}, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+	if alarm.State == models.Cleared || (alarm.Suppression != models.NotSuppressed && alarm.Suppression != models.Shelved) {
+		return models.Alarm{}, ErrNotShelvable
+	}
+
+	audit := auditFrom(ctx)
+	audit.Comment = request.Reason
+	alarm.Suppression = models.Shelved
+	alarm.ShelvedUntil = until.Format(time.RFC3339)
+	alarm.ShelveReason = request.Reason
+	alarm.ShelvedBy = audit.Actor
+	return s.saveTransition(audit, alarm, alarm.State, models.ReasonShelved, now)
+}
+
+// UnshelveAlarm returns a shelved alarm to service before its shelve expires.
+func (s *AlarmService) UnshelveAlarm(ctx context.Context, id string) (models.Alarm, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	alarm, found := s.store.Get(id)
+	if !found {
+		return models.Alarm{}, ErrNotFound
+	}
+	if alarm.Suppression != models.Shelved {
+		return models.Alarm{}, ErrNotShelved
+	}
+	return s.unshelve(auditFrom(ctx), alarm, s.clock.Now())
+}
+
+// unshelve returns a shelved alarm to service and resumes its reminders.
+// Callers must hold the write lock.
+func (s *AlarmService) unshelve(audit Audit, alarm models.Alarm, now time.Time) (models.Alarm, error) {
+	alarm = withoutShelve(alarm)
+	alarm.Suppression = models.NotSuppressed
+	return s.saveTransition(audit, alarm, alarm.State, models.ReasonUnshelved, now)
+}
+
+// withoutShelve returns alarm with the details of a timed shelve removed.
+func withoutShelve(alarm models.Alarm) models.Alarm {
+	alarm.ShelvedUntil = ""
+	alarm.ShelveReason = ""
+	alarm.ShelvedBy = ""
+	return alarm
+}
+
+// shelveUntil validates a shelve request and returns when the shelve expires.
+func shelveUntil(request models.ShelveRequest, now time.Time) (time.Time, error) {
+	if request.Reason == "" {
+		return time.Time{}, &ValidationError{Field: "reason", Message: "shelve reason is mandatory"}
+	}
+
+	var until time.Time
+	switch {
+	case (request.Duration == "") == (request.Until == ""):
+		return time.Time{}, &ValidationError{Field: "duration", Message: "either a shelve duration or an until time is required"}
+	case request.Duration != "":
+		duration, err := time.ParseDuration(request.Duration)
+		if err != nil || duration <= 0 {
+			return time.Time{}, &ValidationError{Field: "duration", Message: "invalid shelve duration, expected a positive Go duration such as 30m"}
+		}
+		until = now.Add(duration)
+	default:
+		parsed, err := time.Parse(time.RFC3339, request.Until)
+		if err != nil || !parsed.After(now) {
+			return time.Time{}, &ValidationError{Field: "until", Message: "invalid shelve until time, expected a future RFC 3339 timestamp"}
+		}
+		until = parsed
+	}
+
+	if until.Sub(now) > maxShelveDuration {
+		return time.Time{}, &ValidationError{Field: "duration", Message: fmt.Sprintf("alarms may be shelved for at most %s", maxShelveDuration)}
+	}
+	return until, nil
+}
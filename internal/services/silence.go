@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+	"github.com/google/uuid"
+)
+
+// CreateSilence stores a silence that suppresses the notifications of matching alarms while
+// it is active. The start defaults to now and the creator to the actor of ctx; an end is
+// required unless the silence recurs.
+func (s *AlarmService) CreateSilence(ctx context.Context, silence models.Silence) (models.Silence, error) {
+	now := s.clock.Now()
+	if silence.StartsAt == "" {
+		silence.StartsAt = now.Format(time.RFC3339)
+	}
+	if err := validateSilence(silence); err != nil {
+		return models.Silence{}, err
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	silence.ID = uuid.New().String()
+	silence.CreatedAt = now.Format(time.RFC3339)
+	if silence.CreatedBy == "" {
+		silence.CreatedBy = auditFrom(ctx).Actor
+	}
+	silence.Status = ""
+	if err := s.apply(store.PutSilence(silence)); err != nil {
+		return models.Silence{}, err
+	}
+	silence.Status = silence.StatusAt(now)
+	return silence, nil
+}
+
+// ListSilences returns every silence, including expired ones, ordered by start time.
+func (s *AlarmService) ListSilences() []models.Silence {
+	now := s.clock.Now()
+
+	s.silenceLock.RLock()
+	silences := make([]models.Silence, 0, len(s.silences))
+	for _, silence := range s.silences {
+		silence.Status = silence.StatusAt(now)
+		silences = append(silences, silence)
+	}
+	s.silenceLock.RUnlock()
+
+	slices.SortFunc(silences, func(a, b models.Silence) int {
+		if c := strings.Compare(a.StartsAt, b.StartsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.ID, b.ID)
+	})
+	return silences
+}
+
+// GetSilence retrieves a silence by its ID.
+func (s *AlarmService) GetSilence(id string) (models.Silence, error) {
+	s.silenceLock.RLock()
+	defer s.silenceLock.RUnlock()
+
+	silence, found := s.silences[id]
+	if !found {
+		return models.Silence{}, ErrSilenceNotFound
+	}
+	silence.Status = silence.StatusAt(s.clock.Now())
+	return silence, nil
+}
+
+// ExpireSilence ends a silence now. Expired silences are kept so that they can still be listed.
+func (s *AlarmService) ExpireSilence(ctx context.Context, id string) (models.Silence, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	silence, err := s.GetSilence(id)
+	if err != nil {
+		return models.Silence{}, err
+	}
+	now := s.clock.Now()
+	if silence.Status == models.SilenceExpired {
+		return silence, nil
+	}
+
+	if silence.Status == models.SilencePending {
+		silence.StartsAt = now.Format(time.RFC3339)
+	}
+	silence.EndsAt = now.Format(time.RFC3339)
+	silence.Status = ""
+	if err := s.apply(store.PutSilence(silence)); err != nil {
+		return models.Silence{}, err
+	}
+	silence.Status = silence.StatusAt(now)
+	return silence, nil
+}
+
+// silenced reports whether an active silence suppresses the notifications of an alarm.
+func (s *AlarmService) silenced(alarm models.Alarm) bool {
+	now := s.clock.Now()
+
+	s.silenceLock.RLock()
+	defer s.silenceLock.RUnlock()
+
+	for _, silence := range s.silences {
+		if silence.Silences(alarm, now) {
+			return true
+		}
+	}
+	return false
+}
+
+// putSilence mirrors a stored silence into the silences consulted when notifying.
+func (s *AlarmService) putSilence(silence models.Silence) {
+	s.silenceLock.Lock()
+	defer s.silenceLock.Unlock()
+
+	s.silences[silence.ID] = silence
+}
+
+// validateSilence verifies the matchers, period and recurrence of a silence.
+func validateSilence(silence models.Silence) error {
+	if len(silence.Matchers) == 0 {
+		return &ValidationError{Field: "matchers", Message: "at least one matcher is required"}
+	}
+	for _, matcher := range silence.Matchers {
+		if err := matcher.Validate(); err != nil {
+			return &ValidationError{Field: "matchers", Message: err.Error()}
+		}
+	}
+
+	startsAt, err := time.Parse(time.RFC3339, silence.StartsAt)
+	if err != nil {
+		return &ValidationError{Field: "starts_at", Message: "invalid starts_at, expected an RFC 3339 timestamp"}
+	}
+	if silence.EndsAt == "" {
+		if silence.Recurrence == nil {
+			return &ValidationError{Field: "ends_at", Message: "ends_at is mandatory for a silence that does not recur"}
+		}
+	} else if endsAt, err := time.Parse(time.RFC3339, silence.EndsAt); err != nil || !endsAt.After(startsAt) {
+		return &ValidationError{Field: "ends_at", Message: "invalid ends_at, expected an RFC 3339 timestamp after starts_at"}
+	}
+
+	if silence.Recurrence != nil {
+		if err := silence.Recurrence.Validate(); err != nil {
+			return &ValidationError{Field: "recurrence", Message: err.Error()}
+		}
+	}
+	return nil
+}
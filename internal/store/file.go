@@ -0,0 +1,283 @@
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+const (
+	// snapshotFile is the name of the file holding the last compacted store contents.
+	snapshotFile = "alarms.json"
+	// walFile is the name of the write-ahead log holding mutations since the last snapshot.
+	walFile = "alarms.wal"
+
+	defaultCompactionInterval  = 5 * time.Minute
+	defaultCompactionThreshold = 1000
+)
+
+// snapshot is the on-disk representation of the store.
+type snapshot struct {
+	Seq       uint64                         `json:"seq"`
+	Alarms    map[string]models.Alarm        `json:"alarms"`
+	Schedule  map[string]time.Time           `json:"schedule"`
+	History   map[string][]models.Transition `json:"history,omitempty"`
+	Silences  map[string]models.Silence      `json:"silences,omitempty"`
+	Overrides map[string]models.Override     `json:"overrides,omitempty"`
+}
+
+// FileOption configures optional FileStore settings.
+type FileOption func(*FileStore)
+
+// WithCompactionInterval sets how often the write-ahead log is compacted into a snapshot.
+// A non-positive interval disables periodic compaction.
+func WithCompactionInterval(interval time.Duration) FileOption {
+	return func(f *FileStore) {
+		f.compactionInterval = interval
+	}
+}
+
+// WithCompactionThreshold sets the number of log entries that triggers an immediate compaction.
+// A non-positive threshold disables size-based compaction.
+func WithCompactionThreshold(entries int) FileOption {
+	return func(f *FileStore) {
+		f.compactionThreshold = entries
+	}
+}
+
+// FileStore persists alarms, their schedule and history, and silences to a local data directory.
+//
+// Reads are served from memory. Every Apply is first appended to a checksummed
+// write-ahead log and only then applied, so a crash never loses an acknowledged
+// mutation. The log is periodically compacted into a snapshot.
+type FileStore struct {
+	MemoryStore
+	dir                 string
+	wal                 *wal
+	seq                 uint64
+	compactionInterval  time.Duration
+	compactionThreshold int
+	done                chan struct{}
+	stop                sync.Once
+	wg                  sync.WaitGroup
+}
+
+// NewFileStore opens (or creates) a FileStore in dir, loading the last snapshot
+// and replaying the write-ahead log on top of it.
+func NewFileStore(dir string, opts ...FileOption) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("failed to create data directory: %w", err)
+	}
+
+	fs := &FileStore{
+		MemoryStore:         MemoryStore{state: newState()},
+		dir:                 dir,
+		compactionInterval:  defaultCompactionInterval,
+		compactionThreshold: defaultCompactionThreshold,
+		done:                make(chan struct{}),
+	}
+	for _, opt := range opts {
+		opt(fs)
+	}
+
+	if err := fs.loadSnapshot(); err != nil {
+		return nil, err
+	}
+	w, err := openWAL(filepath.Join(dir, walFile), fs.replay)
+	if err != nil {
+		return nil, err
+	}
+	fs.wal = w
+
+	if fs.compactionInterval > 0 {
+		fs.wg.Add(1)
+		go fs.startCompactor()
+	}
+	return fs, nil
+}
+
+// Apply logs the given mutations and then applies them to the in-memory state.
+func (f *FileStore) Apply(ops ...Op) error {
+	if len(ops) == 0 {
+		return nil
+	}
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.wal == nil {
+		return errors.New("store is closed")
+	}
+	if err := f.wal.append(walEntry{Seq: f.seq + 1, Ops: ops}); err != nil {
+		return err
+	}
+	f.seq++
+	for _, op := range ops {
+		f.state.apply(op)
+	}
+
+	if f.compactionThreshold > 0 && f.wal.entries >= f.compactionThreshold {
+		if err := f.compact(); err != nil {
+			log.Printf("failed to compact store: %v", err)
+		}
+	}
+	return nil
+}
+
+// Compact writes a snapshot of the current state and truncates the write-ahead log.
+func (f *FileStore) Compact() error {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.wal == nil {
+		return errors.New("store is closed")
+	}
+	return f.compact()
+}
+
+// Close stops background compaction, compacts the log one last time and closes it.
+func (f *FileStore) Close() error {
+	// Wait for the compactor before closing the log, so that it never runs against a
+	// closed store.
+	f.stop.Do(func() { close(f.done) })
+	f.wg.Wait()
+
+	f.lock.Lock()
+	defer f.lock.Unlock()
+
+	if f.wal == nil {
+		return nil
+	}
+	err := f.compact()
+	if closeErr := f.wal.close(); err == nil {
+		err = closeErr
+	}
+	f.wal = nil
+	return err
+}
+
+// startCompactor periodically compacts the write-ahead log until the store is closed.
+func (f *FileStore) startCompactor() {
+	defer f.wg.Done()
+
+	ticker := time.NewTicker(f.compactionInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-f.done:
+			return
+		case <-ticker.C:
+			if err := f.Compact(); err != nil {
+				log.Printf("failed to compact store: %v", err)
+			}
+		}
+	}
+}
+
+// replay applies a log entry recovered at startup, skipping entries already in the snapshot.
+func (f *FileStore) replay(entry walEntry) {
+	if entry.Seq <= f.seq {
+		return
+	}
+	for _, op := range entry.Ops {
+		f.state.apply(op)
+	}
+	f.seq = entry.Seq
+}
+
+// loadSnapshot reads the snapshot file into memory if it exists.
+func (f *FileStore) loadSnapshot() error {
+	data, err := os.ReadFile(filepath.Join(f.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read snapshot: %w", err)
+	}
+
+	var snap snapshot
+	if err := json.Unmarshal(data, &snap); err != nil {
+		return fmt.Errorf("failed to decode snapshot: %w", err)
+	}
+	for id, alarm := range snap.Alarms {
+		f.state.alarms[id] = alarm
+	}
+	for id, at := range snap.Schedule {
+		f.state.schedule[id] = at
+	}
+	for id, transitions := range snap.History {
+		f.state.history[id] = transitions
+	}
+	for id, silence := range snap.Silences {
+		f.state.silences[id] = silence
+	}
+	for id, override := range snap.Overrides {
+		f.state.overrides[id] = override
+	}
+	f.seq = snap.Seq
+	return nil
+}
+
+// compact writes the current state to the snapshot file via a temporary file
+// and rename, syncs the directory so the rename is durable, then resets the
+// log. Callers must hold the write lock.
+func (f *FileStore) compact() error {
+	if f.wal.entries == 0 {
+		return nil
+	}
+
+	data, err := json.Marshal(snapshot{Seq: f.seq, Alarms: f.state.alarms, Schedule: f.state.schedule, History: f.state.history, Silences: f.state.silences, Overrides: f.state.overrides})
+	if err != nil {
+		return fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+
+	tmp := filepath.Join(f.dir, snapshotFile+".tmp")
+	if err := writeFileSync(tmp, data); err != nil {
+		return fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(f.dir, snapshotFile)); err != nil {
+		return fmt.Errorf("failed to replace snapshot: %w", err)
+	}
+	if err := syncDir(f.dir); err != nil {
+		return fmt.Errorf("failed to sync data directory: %w", err)
+	}
+	return f.wal.reset()
+}
+
+// syncDir syncs the directory at path, persisting the entries renamed into it.
+func syncDir(path string) error {
+	dir, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	if err := dir.Sync(); err != nil {
+		dir.Close()
+		return err
+	}
+	return dir.Close()
+}
+
+// writeFileSync writes data to path and syncs it before returning.
+func writeFileSync(path string, data []byte) error {
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
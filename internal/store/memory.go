@@ -0,0 +1,109 @@
+package store
+
+import (
+	"sync"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// MemoryStore keeps alarms and their schedule in process memory only.
+type MemoryStore struct {
+	lock  sync.RWMutex
+	state state
+}
+
+// NewMemoryStore initializes and returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{state: newState()}
+}
+
+// Get returns the alarm with the given ID.
+func (m *MemoryStore) Get(id string) (models.Alarm, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	alarm, found := m.state.alarms[id]
+	return alarm, found
+}
+
+// List returns every stored alarm.
+func (m *MemoryStore) List() []models.Alarm {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	alarms := make([]models.Alarm, 0, len(m.state.alarms))
+	for _, alarm := range m.state.alarms {
+		alarms = append(alarms, alarm)
+	}
+	return alarms
+}
+
+// NextNotification returns the scheduled notification time for an alarm.
+func (m *MemoryStore) NextNotification(id string) (time.Time, bool) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	at, found := m.state.schedule[id]
+	return at, found
+}
+
+// Schedule returns a copy of the notification schedule.
+func (m *MemoryStore) Schedule() map[string]time.Time {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	schedule := make(map[string]time.Time, len(m.state.schedule))
+	for id, at := range m.state.schedule {
+		schedule[id] = at
+	}
+	return schedule
+}
+
+// History returns a copy of the recorded transitions of an alarm.
+func (m *MemoryStore) History(id string) []models.Transition {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	return append([]models.Transition{}, m.state.history[id]...)
+}
+
+// Silences returns every stored silence.
+func (m *MemoryStore) Silences() []models.Silence {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	silences := make([]models.Silence, 0, len(m.state.silences))
+	for _, silence := range m.state.silences {
+		silences = append(silences, silence)
+	}
+	return silences
+}
+
+// Overrides returns every stored on-call override.
+func (m *MemoryStore) Overrides() []models.Override {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	overrides := make([]models.Override, 0, len(m.state.overrides))
+	for _, override := range m.state.overrides {
+		overrides = append(overrides, override)
+	}
+	return overrides
+}
+
+// Apply applies the given mutations.
+func (m *MemoryStore) Apply(ops ...Op) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	for _, op := range ops {
+		m.state.apply(op)
+	}
+	return nil
+}
+
+// Close is a no-op for MemoryStore.
+func (m *MemoryStore) Close() error {
+	return nil
+}
@@ -0,0 +1,150 @@
+// Package store provides persistence backends for alarms and their notification schedule.
+package store
+
+import (
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+)
+
+// OpKind identifies the type of mutation carried by an Op.
+type OpKind string
+
+const (
+	OpPutAlarm       OpKind = "put_alarm"
+	OpDeleteAlarm    OpKind = "delete_alarm"
+	OpSchedule       OpKind = "schedule"
+	OpUnschedule     OpKind = "unschedule"
+	OpRecord         OpKind = "record"
+	OpPutSilence     OpKind = "put_silence"
+	OpPutOverride    OpKind = "put_override"
+	OpDeleteOverride OpKind = "delete_override"
+)
+
+// Op is a single mutation applied to an AlarmStore.
+type Op struct {
+	Kind       OpKind             `json:"kind"`                 // Type of mutation
+	ID         string             `json:"id"`                   // Alarm ID the mutation applies to
+	Alarm      *models.Alarm      `json:"alarm,omitempty"`      // Alarm payload for OpPutAlarm
+	At         time.Time          `json:"at,omitempty"`         // Next notification time for OpSchedule
+	Transition *models.Transition `json:"transition,omitempty"` // History entry for OpRecord
+	Silence    *models.Silence    `json:"silence,omitempty"`    // Silence payload for OpPutSilence
+	Override   *models.Override   `json:"override,omitempty"`   // On-call override payload for OpPutOverride
+}
+
+// PutAlarm returns an Op that inserts or replaces an alarm.
+func PutAlarm(alarm models.Alarm) Op {
+	return Op{Kind: OpPutAlarm, ID: alarm.ID, Alarm: &alarm}
+}
+
+// DeleteAlarm returns an Op that removes an alarm together with its schedule entry.
+func DeleteAlarm(id string) Op {
+	return Op{Kind: OpDeleteAlarm, ID: id}
+}
+
+// Schedule returns an Op that sets the next notification time of an alarm.
+func Schedule(id string, at time.Time) Op {
+	return Op{Kind: OpSchedule, ID: id, At: at}
+}
+
+// Unschedule returns an Op that removes any pending notification for an alarm.
+func Unschedule(id string) Op {
+	return Op{Kind: OpUnschedule, ID: id}
+}
+
+// Record returns an Op that appends a transition to the history of an alarm.
+func Record(transition models.Transition) Op {
+	return Op{Kind: OpRecord, ID: transition.AlarmID, Transition: &transition}
+}
+
+// PutSilence returns an Op that inserts or replaces a silence.
+func PutSilence(silence models.Silence) Op {
+	return Op{Kind: OpPutSilence, ID: silence.ID, Silence: &silence}
+}
+
+// PutOverride returns an Op that inserts or replaces an on-call override.
+func PutOverride(override models.Override) Op {
+	return Op{Kind: OpPutOverride, ID: override.ID, Override: &override}
+}
+
+// DeleteOverride returns an Op that removes an on-call override.
+func DeleteOverride(id string) Op {
+	return Op{Kind: OpDeleteOverride, ID: id}
+}
+
+// AlarmStore persists alarms, their notification schedule and their history, silences and
+// on-call overrides.
+//
+// All mutations go through Apply so that the ops produced by a single service
+// call are persisted as one unit. Implementations must be safe for concurrent use.
+type AlarmStore interface {
+	// Get returns the alarm with the given ID.
+	Get(id string) (models.Alarm, bool)
+	// List returns every stored alarm in no particular order.
+	List() []models.Alarm
+	// NextNotification returns the scheduled notification time for an alarm.
+	NextNotification(id string) (time.Time, bool)
+	// Schedule returns a copy of the whole notification schedule.
+	Schedule() map[string]time.Time
+	// History returns the recorded transitions of an alarm, including a deleted one.
+	History(id string) []models.Transition
+	// Silences returns every stored silence in no particular order, including expired ones.
+	Silences() []models.Silence
+	// Overrides returns every stored on-call override in no particular order.
+	Overrides() []models.Override
+	// Apply atomically applies the given mutations.
+	Apply(ops ...Op) error
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+// state holds the in-memory view shared by every store implementation.
+type state struct {
+	alarms    map[string]models.Alarm
+	schedule  map[string]time.Time
+	history   map[string][]models.Transition
+	silences  map[string]models.Silence
+	overrides map[string]models.Override
+}
+
+// newState returns an empty state.
+func newState() state {
+	return state{
+		alarms:    make(map[string]models.Alarm),
+		schedule:  make(map[string]time.Time),
+		history:   make(map[string][]models.Transition),
+		silences:  make(map[string]models.Silence),
+		overrides: make(map[string]models.Override),
+	}
+}
+
+// apply mutates the state according to the given op.
+func (st *state) apply(op Op) {
+	switch op.Kind {
+	case OpPutAlarm:
+		if op.Alarm != nil {
+			st.alarms[op.ID] = *op.Alarm
+		}
+	case OpDeleteAlarm:
+		delete(st.alarms, op.ID)
+		delete(st.schedule, op.ID)
+	case OpSchedule:
+		st.schedule[op.ID] = op.At
+	case OpUnschedule:
+		delete(st.schedule, op.ID)
+	case OpRecord:
+		if op.Transition != nil {
+			st.history[op.ID] = append(st.history[op.ID], *op.Transition)
+		}
+	case OpPutSilence:
+		if op.Silence != nil {
+			st.silences[op.ID] = *op.Silence
+		}
+	case OpPutOverride:
+		if op.Override != nil {
+			st.overrides[op.ID] = *op.Override
+		}
+	case OpDeleteOverride:
+		delete(st.overrides, op.ID)
+	}
+}
@@ -0,0 +1,319 @@
+package store_test
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/deeprajsshetty/alarm-service/internal/models"
+	"github.com/deeprajsshetty/alarm-service/internal/store"
+)
+
+// TestMemoryStore_Apply verifies put, schedule and delete mutations on the in-memory store.
+func TestMemoryStore_Apply(t *testing.T) {
+	st := store.NewMemoryStore()
+	alarm := models.Alarm{ID: "a1", Name: "CPU Overload", State: models.Triggered}
+	next := time.Now().Add(time.Hour).UTC()
+
+	if err := st.Apply(store.PutAlarm(alarm), store.Schedule(alarm.ID, next)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+
+	got, found := st.Get(alarm.ID)
+	if !found || got.Name != alarm.Name {
+		t.Errorf("expected alarm %q, got %+v (found=%v)", alarm.Name, got, found)
+	}
+	if at, found := st.NextNotification(alarm.ID); !found || !at.Equal(next) {
+		t.Errorf("expected next notification %v, got %v (found=%v)", next, at, found)
+	}
+
+	if err := st.Apply(store.DeleteAlarm(alarm.ID)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if _, found := st.Get(alarm.ID); found {
+		t.Errorf("expected alarm to be deleted")
+	}
+	if len(st.Schedule()) != 0 {
+		t.Errorf("expected schedule entry to be removed with the alarm")
+	}
+}
+
+// TestFileStore_Reload verifies alarms, schedule, history and silences survive reopening the data directory.
+func TestFileStore_Reload(t *testing.T) {
+	dir := t.TempDir()
+	alarm := models.Alarm{ID: "a1", Name: "Disk Space Alert", State: models.Triggered}
+	next := time.Now().Add(2 * time.Hour).UTC()
+
+	st, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	if err := st.Apply(store.PutAlarm(alarm), store.Schedule(alarm.ID, next)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	deleted := models.Transition{AlarmID: "a0", From: models.Cleared, Reason: models.ReasonDeleted}
+	if err := st.Apply(store.Record(models.Transition{AlarmID: "a0", To: models.Cleared}), store.Record(deleted), store.DeleteAlarm("a0")); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	silence := models.Silence{ID: "s1", Matchers: []models.Matcher{{Name: "team", Value: "storage"}}, Comment: "maintenance"}
+	override := models.Override{ID: "o1", ScheduleID: "database", UserID: "alice"}
+	if err := st.Apply(store.PutSilence(silence), store.PutOverride(override)); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("failed to close store: %v", err)
+	}
+
+	reopened, err := store.NewFileStore(dir)
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+
+	got, found := reopened.Get(alarm.ID)
+	if !found || got.Name != alarm.Name {
+		t.Errorf("expected alarm %q after reload, got %+v (found=%v)", alarm.Name, got, found)
+	}
+	if at, found := reopened.NextNotification(alarm.ID); !found || !at.Equal(next) {
+		t.Errorf("expected next notification %v after reload, got %v (found=%v)", next, at, found)
+	}
+	if history := reopened.History("a0"); len(history) != 2 || history[1].Reason != models.ReasonDeleted {
+		t.Errorf("expected history of a deleted alarm after reload, got %+v", history)
+	}
+	if silences := reopened.Silences(); len(silences) != 1 || silences[0].Comment != silence.Comment {
+		t.Errorf("expected silence after reload, got %+v", silences)
+	}
+	if overrides := reopened.Overrides(); len(overrides) != 1 || overrides[0].UserID != override.UserID {
+		t.Errorf("expected override after reload, got %+v", overrides)
+	}
+}
+
+// TestFileStore_ReplayWithoutClose verifies logged mutations are recovered after a crash.
+func TestFileStore_ReplayWithoutClose(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	st.Apply(store.PutAlarm(models.Alarm{ID: "a1", Name: "Alarm 1", State: models.Triggered}))
+	st.Apply(store.PutAlarm(models.Alarm{ID: "a2", Name: "Alarm 2", State: models.Active}))
+	st.Apply(store.DeleteAlarm("a1"))
+
+	// Reopen without closing to simulate a crash.
+	recovered, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer recovered.Close()
+
+	if _, found := recovered.Get("a1"); found {
+		t.Errorf("expected deleted alarm a1 to stay deleted after replay")
+	}
+	if _, found := recovered.Get("a2"); !found {
+		t.Errorf("expected alarm a2 to be recovered from the log")
+	}
+}
+
+// TestFileStore_CorruptedTail verifies a damaged log tail is truncated instead of failing startup.
+func TestFileStore_CorruptedTail(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	st.Apply(store.PutAlarm(models.Alarm{ID: "a1", Name: "Alarm 1", State: models.Triggered}))
+
+	walPath := filepath.Join(dir, "alarms.wal")
+	intact, err := os.Stat(walPath)
+	if err != nil {
+		t.Fatalf("failed to stat log: %v", err)
+	}
+
+	file, err := os.OpenFile(walPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	file.Write([]byte{0x20, 0x00, 0x00, 0x00, 0xde, 0xad, 0xbe, 0xef, '{', '"'})
+	file.Close()
+
+	recovered, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("expected corrupted tail to be tolerated, got %v", err)
+	}
+	defer recovered.Close()
+
+	if _, found := recovered.Get("a1"); !found {
+		t.Errorf("expected intact entry before the corrupted tail to be replayed")
+	}
+	if info, err := os.Stat(walPath); err != nil || info.Size() != intact.Size() {
+		t.Errorf("expected log to be truncated to %d bytes, got %v (err=%v)", intact.Size(), info.Size(), err)
+	}
+
+	// New entries must be appended after the truncated tail and be replayable.
+	if err := recovered.Apply(store.PutAlarm(models.Alarm{ID: "a2", Name: "Alarm 2", State: models.Triggered})); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	again, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer again.Close()
+	if len(again.List()) != 2 {
+		t.Errorf("expected 2 alarms after reopening, got %d", len(again.List()))
+	}
+}
+
+// TestFileStore_OversizedEntryLength verifies a header claiming more bytes than the log holds is
+// treated as a corrupted tail instead of being allocated.
+func TestFileStore_OversizedEntryLength(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	st.Apply(store.PutAlarm(models.Alarm{ID: "a1", Name: "Alarm 1", State: models.Triggered}))
+
+	walPath := filepath.Join(dir, "alarms.wal")
+	intact, err := os.Stat(walPath)
+	if err != nil {
+		t.Fatalf("failed to stat log: %v", err)
+	}
+
+	file, err := os.OpenFile(walPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("failed to open log: %v", err)
+	}
+	file.Write([]byte{0xff, 0xff, 0xff, 0xff, 0xde, 0xad, 0xbe, 0xef, '{', '"'})
+	file.Close()
+
+	recovered, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("expected oversized entry length to be tolerated, got %v", err)
+	}
+	defer recovered.Close()
+
+	if _, found := recovered.Get("a1"); !found {
+		t.Errorf("expected intact entry before the corrupted tail to be replayed")
+	}
+	if info, err := os.Stat(walPath); err != nil || info.Size() != intact.Size() {
+		t.Errorf("expected log to be truncated to %d bytes, got %v (err=%v)", intact.Size(), info.Size(), err)
+	}
+}
+
+// TestFileStore_CorruptedEntry verifies a checksum mismatch fails startup when entries follow it,
+// and is truncated as a torn write when it is the final entry.
+func TestFileStore_CorruptedEntry(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	st.Apply(store.PutAlarm(models.Alarm{ID: "a1", Name: "Alarm 1", State: models.Triggered}))
+	walPath := filepath.Join(dir, "alarms.wal")
+	first, err := os.Stat(walPath)
+	if err != nil {
+		t.Fatalf("failed to stat log: %v", err)
+	}
+	st.Apply(store.PutAlarm(models.Alarm{ID: "a2", Name: "Alarm 2", State: models.Triggered}))
+
+	// Flip a payload byte of the first entry, leaving the second intact after it.
+	data, err := os.ReadFile(walPath)
+	if err != nil {
+		t.Fatalf("failed to read log: %v", err)
+	}
+	data[first.Size()-2] ^= 0xff
+	if err := os.WriteFile(walPath, data, 0o644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	if _, err := store.NewFileStore(dir, store.WithCompactionInterval(0)); err == nil {
+		t.Fatal("expected a corrupted entry followed by intact entries to fail startup")
+	}
+
+	// The same damage to the final entry is a torn write.
+	if err := os.WriteFile(walPath, data[:first.Size()], 0o644); err != nil {
+		t.Fatalf("failed to write log: %v", err)
+	}
+	recovered, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("expected a corrupted final entry to be tolerated, got %v", err)
+	}
+	defer recovered.Close()
+	if info, err := os.Stat(walPath); err != nil || info.Size() != 0 {
+		t.Errorf("expected the corrupted final entry to be truncated, got %v (err=%v)", info.Size(), err)
+	}
+}
+
+// TestFileStore_Compaction verifies the log is folded into a snapshot once the threshold is reached.
+func TestFileStore_Compaction(t *testing.T) {
+	dir := t.TempDir()
+
+	st, err := store.NewFileStore(dir, store.WithCompactionInterval(0), store.WithCompactionThreshold(3))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	for i := 0; i < 3; i++ {
+		st.Apply(store.PutAlarm(models.Alarm{ID: fmt.Sprintf("a%d", i), Name: "Alarm", State: models.Triggered}))
+	}
+
+	if info, err := os.Stat(filepath.Join(dir, "alarms.wal")); err != nil || info.Size() != 0 {
+		t.Errorf("expected empty log after compaction, got %v (err=%v)", info.Size(), err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, "alarms.json")); err != nil {
+		t.Errorf("expected snapshot to be written, got %v", err)
+	}
+
+	st.Apply(store.DeleteAlarm("a0"))
+	reopened, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+	if len(reopened.List()) != 2 {
+		t.Errorf("expected 2 alarms from snapshot plus log, got %d", len(reopened.List()))
+	}
+}
+
+// TestFileStore_CloseStopsCompactor verifies closing a store waits for its compactor, so that
+// it never compacts a closed store, and that closing it again is a no-op.
+func TestFileStore_CloseStopsCompactor(t *testing.T) {
+	var logs bytes.Buffer
+	log.SetOutput(&logs)
+	defer log.SetOutput(os.Stderr)
+
+	dir := t.TempDir()
+	st, err := store.NewFileStore(dir, store.WithCompactionInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("failed to open store: %v", err)
+	}
+	for i := 0; i < 20; i++ {
+		st.Apply(store.PutAlarm(models.Alarm{ID: fmt.Sprintf("a%d", i), Name: "Alarm", State: models.Triggered}))
+		time.Sleep(100 * time.Microsecond)
+	}
+	if err := st.Close(); err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if err := st.Close(); err != nil {
+		t.Errorf("expected closing twice to be a no-op, got %v", err)
+	}
+	if strings.Contains(logs.String(), "store is closed") {
+		t.Errorf("expected the compactor to stop before the store closed, got logs %q", logs.String())
+	}
+
+	reopened, err := store.NewFileStore(dir, store.WithCompactionInterval(0))
+	if err != nil {
+		t.Fatalf("failed to reopen store: %v", err)
+	}
+	defer reopened.Close()
+	if len(reopened.List()) != 20 {
+		t.Errorf("expected 20 alarms after reopening, got %d", len(reopened.List()))
+	}
+}
@@ -0,0 +1,180 @@
+package store
+
+import (
+	"bufio"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"log"
+	"os"
+)
+
+// walHeaderSize is the size of the length and checksum prefix of every log entry.
+const walHeaderSize = 8
+
+// crcTable is the CRC-32C table used to checksum log entries.
+var crcTable = crc32.MakeTable(crc32.Castagnoli)
+
+// walEntry is a single mutation recorded in the write-ahead log.
+type walEntry struct {
+	Seq uint64 `json:"seq"` // Monotonic sequence number of the entry
+	Ops []Op   `json:"ops"` // Mutations applied as one unit
+}
+
+// wal is an append-only log of store mutations.
+//
+// Each entry is framed as a 4-byte little-endian payload length, a 4-byte
+// CRC-32C of the payload and the JSON-encoded walEntry.
+type wal struct {
+	file    *os.File
+	entries int
+}
+
+// openWAL opens the log at path, replays every intact entry through fn and
+// truncates a partially written or corrupted final entry. A corrupted entry
+// followed by more of the log fails instead, as it cannot come from a torn write.
+func openWAL(path string, fn func(walEntry)) (*wal, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open write-ahead log: %w", err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat write-ahead log: %w", err)
+	}
+
+	w := &wal{file: file}
+	valid, err := w.replay(info.Size(), fn)
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	if info.Size() > valid {
+		log.Printf("write-ahead log %s: truncating %d corrupted bytes at offset %d", path, info.Size()-valid, valid)
+		if err := file.Truncate(valid); err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to truncate write-ahead log: %w", err)
+		}
+	}
+	if _, err := file.Seek(valid, io.SeekStart); err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to seek write-ahead log: %w", err)
+	}
+	return w, nil
+}
+
+// replay decodes entries from the start of the log, whose size in bytes is
+// given, and returns the offset of the end of the last intact entry. Only the
+// final entry can be torn by a crash, since every append is synced before the
+// next; an entry failing its checksum or decoding with data after it is an
+// error.
+func (w *wal) replay(fileSize int64, fn func(walEntry)) (int64, error) {
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return 0, fmt.Errorf("failed to seek write-ahead log: %w", err)
+	}
+
+	reader := bufio.NewReader(w.file)
+	var offset int64
+	header := make([]byte, walHeaderSize)
+	for {
+		if _, err := io.ReadFull(reader, header); err != nil {
+			return offset, nil
+		}
+		size := binary.LittleEndian.Uint32(header[0:4])
+		checksum := binary.LittleEndian.Uint32(header[4:8])
+
+		// A length running past the end of the file can only come from a
+		// corrupted header; reject it before allocating the payload.
+		if int64(size) > fileSize-offset-walHeaderSize {
+			return offset, nil
+		}
+		payload := make([]byte, size)
+		if _, err := io.ReadFull(reader, payload); err != nil {
+			return offset, nil
+		}
+		end := offset + walHeaderSize + int64(size)
+		if crc32.Checksum(payload, crcTable) != checksum {
+			if end < fileSize {
+				return 0, fmt.Errorf("write-ahead log corrupted: checksum mismatch in entry at offset %d followed by %d bytes", offset, fileSize-end)
+			}
+			return offset, nil
+		}
+
+		var entry walEntry
+		if err := json.Unmarshal(payload, &entry); err != nil {
+			if end < fileSize {
+				return 0, fmt.Errorf("write-ahead log corrupted: failed to decode entry at offset %d followed by %d bytes: %w", offset, fileSize-end, err)
+			}
+			return offset, nil
+		}
+		fn(entry)
+		offset = end
+		w.entries++
+	}
+}
+
+// append writes an entry to the log and syncs it to disk.
+func (w *wal) append(entry walEntry) error {
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("failed to encode log entry: %w", err)
+	}
+
+	record := make([]byte, walHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(record[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(record[4:8], crc32.Checksum(payload, crcTable))
+	copy(record[walHeaderSize:], payload)
+
+	offset, err := w.file.Seek(0, io.SeekCurrent)
+	if err != nil {
+		return fmt.Errorf("failed to seek write-ahead log: %w", err)
+	}
+	if _, err := w.file.Write(record); err != nil {
+		return w.rollback(offset, fmt.Errorf("failed to append log entry: %w", err))
+	}
+	if err := w.file.Sync(); err != nil {
+		return w.rollback(offset, fmt.Errorf("failed to sync write-ahead log: %w", err))
+	}
+	w.entries++
+	return nil
+}
+
+// rollback discards a partially written entry by truncating the log back to
+// offset, so the next append does not land after a corrupted record. It
+// returns cause, joined with any error hit while rolling back.
+func (w *wal) rollback(offset int64, cause error) error {
+	if err := w.file.Truncate(offset); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to truncate write-ahead log: %w", err))
+	}
+	if _, err := w.file.Seek(offset, io.SeekStart); err != nil {
+		return errors.Join(cause, fmt.Errorf("failed to seek write-ahead log: %w", err))
+	}
+	return cause
+}
+
+// reset discards every entry in the log.
+func (w *wal) reset() error {
+	if err := w.file.Truncate(0); err != nil {
+		return fmt.Errorf("failed to truncate write-ahead log: %w", err)
+	}
+	if _, err := w.file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to seek write-ahead log: %w", err)
+	}
+	w.entries = 0
+	return nil
+}
+
+// close closes the underlying log file.
+func (w *wal) close() error {
+	if w.file == nil {
+		return errors.New("write-ahead log already closed")
+	}
+	err := w.file.Close()
+	w.file = nil
+	return err
+}